@@ -1,10 +1,11 @@
 package asol
 
 import (
+	"context"
 	"crypto/tls"
 	"encoding/json"
 	"fmt"
-	"io"
+	"net"
 	"net/http"
 	"strings"
 	"sync"
@@ -18,17 +19,34 @@ import (
 	"github.com/gorilla/websocket"
 )
 
+const (
+	MaximumMalformedFrames int = 10
+)
+
 type (
 	Asol struct {
 		*cem.ConnectionEventManager
 		*wem.WebsocketEventManager
 
-		client     *request.HTTPClient
-		connection *websocket.Conn
-		search     *game.Search
-		game       *game.Game
-		mutex      *sync.Mutex
-		status     bool
+		client      *request.HTTPClient
+		socket      *writer
+		discovery   game.Discovery
+		game        *game.Game
+		mutex       *sync.Mutex
+		cancel      context.CancelFunc
+		done        chan struct{}
+		backoff     *Backoff
+		keepalive   *Keepalive
+		calls       *wem.CallManager
+		callTimeout time.Duration
+		events      map[string]struct{}
+		handlers    map[string]int
+		dispatcher  *wem.Dispatcher
+		sessionID   string
+		streams     map[int]context.CancelFunc
+		identifier  int
+		state       cem.State
+		recorder    *wem.Recorder
 	}
 
 	Login struct {
@@ -45,8 +63,31 @@ type (
 		UserAuthToken  string
 		Username       string
 	}
+
+	NotConnectedError struct{}
+
+	SelectorError struct {
+		Discovery game.Discovery
+	}
+
+	TooManyMalformedFramesError struct {
+		Count int
+		Last  error
+	}
 )
 
+func (error *TooManyMalformedFramesError) Error() string {
+	return fmt.Sprintf("%d consecutive frames were malformed; the last was: %v", error.Count, error.Last)
+}
+
+func (error *SelectorError) Error() string {
+	return fmt.Sprintf("A selector cannot be applied to a %T discovery", error.Discovery)
+}
+
+func (error *NotConnectedError) Error() string {
+	return "The websocket is not connected"
+}
+
 func (login *Login) isReady() bool {
 	var state string = strings.ToLower(login.State)
 
@@ -61,17 +102,36 @@ func (login *Login) isReady() bool {
 }
 
 func NewAsol() *Asol {
-	return &Asol{
-		&cem.ConnectionEventManager{},
-		&wem.WebsocketEventManager{},
+	asol := &Asol{
+		cem.NewConnectionEventManager(),
+		wem.NewWebsocketEventManager(),
 
 		request.NewHTTPClient(),
-		&websocket.Conn{},
-		&game.Search{},
+		nil,
+		game.NewSearch(),
 		nil,
 		&sync.Mutex{},
-		false,
+		nil,
+		nil,
+		NewBackoff(),
+		NewKeepalive(),
+		wem.NewCallManager(),
+		DefaultCallTimeout,
+		make(map[string]struct{}),
+		make(map[string]int),
+		nil,
+		"",
+		make(map[int]context.CancelFunc),
+		0,
+		cem.Idle,
+		nil,
 	}
+
+	asol.OnRegister(asol.onRegister)
+	asol.OnUnregister(asol.onUnregister)
+	asol.OnError(asol.EmitHandlerError)
+
+	return asol
 }
 
 func (asol *Asol) Client() *request.HTTPClient {
@@ -79,11 +139,21 @@ func (asol *Asol) Client() *request.HTTPClient {
 }
 
 func (asol *Asol) Game() *game.Game {
+	asol.mutex.Lock()
+	defer asol.mutex.Unlock()
+
 	return asol.game
 }
 
+func (asol *Asol) State() cem.State {
+	asol.mutex.Lock()
+	defer asol.mutex.Unlock()
+
+	return asol.state
+}
+
 func (asol *Asol) isGameRunning() bool {
-	if asol.game == nil {
+	if asol.Game() == nil {
 		return false
 	}
 
@@ -91,155 +161,506 @@ func (asol *Asol) isGameRunning() bool {
 }
 
 func (asol *Asol) isRunning() bool {
-	return asol.status
+	switch asol.State() {
+	case cem.Idle, cem.Disconnected, cem.Stopped:
+		return false
+	}
+
+	return true
 }
 
-func (asol *Asol) setSearch(search *game.Search) {
-	asol.search = search
+func (asol *Asol) isStopped() bool {
+	return asol.State() == cem.Stopped
 }
 
-func (asol *Asol) setStatus(status bool) {
-	asol.status = status
+func (asol *Asol) SetDiscovery(discovery game.Discovery) {
+	asol.discovery = discovery
+}
+
+func (asol *Asol) SetProcess() {
+	asol.discovery = game.NewSearch()
+}
+
+func (asol *Asol) SetSelector(selector game.Selector) error {
+	search, ok := asol.discovery.(*game.Search)
+
+	if !ok {
+		return &SelectorError{asol.discovery}
+	}
+
+	search.SetSelector(selector)
+	return nil
+}
+
+func (asol *Asol) Clients() ([]*game.Game, error) {
+	search, ok := asol.discovery.(*game.Search)
+
+	if !ok {
+		search = game.NewSearch()
+	}
+
+	return search.Clients()
+}
+
+func (asol *Asol) SetAuthorization(authorization *authorization.Authorization) {
+	asol.discovery = game.NewCredential(authorization)
+}
+
+func (asol *Asol) SetEnvironment() error {
+	authorization, err := authorization.FromEnvironment()
+
+	if err != nil {
+		return err
+	}
+
+	asol.SetAuthorization(authorization)
+	return nil
+}
+
+func (asol *Asol) SetFile(path string) error {
+	authorization, err := authorization.FromFile(path)
+
+	if err != nil {
+		return err
+	}
+
+	asol.SetAuthorization(authorization)
+	return nil
+}
+
+func (asol *Asol) SetBackoff(backoff *Backoff) {
+	asol.backoff = backoff
+}
+
+func (asol *Asol) SessionID() string {
+	asol.mutex.Lock()
+	defer asol.mutex.Unlock()
+
+	return asol.sessionID
+}
+
+func (asol *Asol) setSessionID(sessionID string) {
+	asol.mutex.Lock()
+	defer asol.mutex.Unlock()
+
+	asol.sessionID = sessionID
+}
+
+func (asol *Asol) SetDispatcher(dispatcher *wem.Dispatcher) {
+	asol.mutex.Lock()
+	defer asol.mutex.Unlock()
+
+	asol.dispatcher = dispatcher
+}
+
+func (asol *Asol) Dispatcher() *wem.Dispatcher {
+	asol.mutex.Lock()
+	defer asol.mutex.Unlock()
+
+	return asol.dispatcher
+}
+
+func (asol *Asol) SetRecorder(recorder *wem.Recorder) {
+	asol.mutex.Lock()
+	defer asol.mutex.Unlock()
+
+	asol.recorder = recorder
+}
+
+func (asol *Asol) Recorder() *wem.Recorder {
+	asol.mutex.Lock()
+	defer asol.mutex.Unlock()
+
+	return asol.recorder
+}
+
+func (asol *Asol) SetLockfile(path string) {
+	asol.discovery = game.NewLockfile(path)
+}
+
+func (asol *Asol) begin(ctx context.Context) (context.Context, func()) {
+	ctx, cancel := context.WithCancel(ctx)
+	done := make(chan struct{})
+
+	asol.mutex.Lock()
+	asol.cancel = cancel
+	asol.done = done
+	asol.mutex.Unlock()
+
+	return ctx, func() {
+		cancel()
+
+		asol.mutex.Lock()
+		asol.cancel = nil
+		asol.done = nil
+		asol.mutex.Unlock()
+
+		close(done)
+	}
+}
+
+func (asol *Asol) connection() *writer {
+	asol.mutex.Lock()
+	defer asol.mutex.Unlock()
+
+	return asol.socket
+}
+
+func (asol *Asol) setConnection(socket *writer) {
+	asol.mutex.Lock()
+	defer asol.mutex.Unlock()
+
+	asol.socket = socket
+}
+
+func (asol *Asol) write(message interface{}) error {
+	socket := asol.connection()
+
+	if socket == nil {
+		return &NotConnectedError{}
+	}
+
+	return socket.send(&outgoing{message: message})
+}
+
+func (asol *Asol) setState(state cem.State) error {
+	asol.mutex.Lock()
+	var previous cem.State = asol.state
+
+	if previous == state {
+		asol.mutex.Unlock()
+		return nil
+	}
+
+	if !previous.CanTransition(state) {
+		asol.mutex.Unlock()
+		return &cem.InvalidTransitionError{From: previous, To: state}
+	}
+
+	asol.state = state
+	asol.mutex.Unlock()
+
+	asol.EmitStateChange(previous, state)
+
+	return nil
 }
 
 func (asol *Asol) setGame(game *game.Game) {
+	asol.mutex.Lock()
+	defer asol.mutex.Unlock()
+
 	asol.game = game
 }
 
-func (asol *Asol) isReady() {
+func (asol *Asol) wait(ctx context.Context) error {
+	timer := time.NewTimer(1000 * time.Millisecond)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (asol *Asol) isReady(ctx context.Context) error {
 	for {
-		asol.client.SetWebsocket()
-		request, _ := asol.client.Get("/riotclient/region-locale")
-		_, err := asol.client.Request(request)
+		local := asol.client.Local()
+		request, _ := local.Get("/riotclient/region-locale")
+		_, err := local.Request(asol.client, request.WithContext(ctx))
 
 		if err == nil {
-			break
+			return nil
 		}
 
-		time.Sleep(1000 * time.Millisecond)
+		err = asol.wait(ctx)
+
+		if err != nil {
+			return err
+		}
 	}
 }
 
-func (asol *Asol) isLoggedIn() {
+func (asol *Asol) isLoggedIn(ctx context.Context) error {
 	for {
-		asol.client.SetWebsocket()
-		request, _ := asol.client.Get("/lol-login/v1/session")
-		data, err := asol.client.Request(request)
+		local := asol.client.Local()
+		request, _ := local.Get("/lol-login/v1/session")
+		data, err := local.Request(asol.client, request.WithContext(ctx))
 
-		if err != nil {
-			continue
+		if err == nil {
+			var login Login
+			json.Unmarshal(data, &login)
+
+			if login.isReady() {
+				return nil
+			}
 		}
 
-		var login Login
-		json.Unmarshal(data, &login)
+		err = asol.wait(ctx)
 
-		if login.isReady() {
-			break
+		if err != nil {
+			return err
 		}
-
-		time.Sleep(1000 * time.Millisecond)
 	}
 }
 
 func (asol *Asol) Start() {
-	asol.OnSearchCallback()
+	asol.Run(context.Background())
+}
+
+func (asol *Asol) Run(ctx context.Context) error {
+	err := asol.setState(cem.Searching)
+
+	if err != nil {
+		return err
+	}
+
+	ctx, finish := asol.begin(ctx)
+	defer finish()
+
+	if asol.isStopped() {
+		return nil
+	}
 
-	var search *game.Search = game.NewSearch()
-	asol.setSearch(search)
+	_, err = asol.session(ctx)
 
-	process, err := asol.search.Start()
+	if err != nil && asol.isStopped() {
+		return nil
+	}
+
+	return err
+}
 
-	if err == nil {
-		var game *game.Game = game.NewGame(process)
-		var authorization *authorization.Authorization = game.Authorization()
+func (asol *Asol) Supervise(ctx context.Context) error {
+	err := asol.setState(cem.Searching)
 
-		asol.client.SetAuthorization(authorization)
-		asol.setGame(game)
+	if err != nil {
+		return err
+	}
 
-		asol.setStatus(true)
+	ctx, finish := asol.begin(ctx)
+	defer finish()
 
-		if asol.game == nil {
-			asol.setStatus(false)
-			asol.OnProcessErrorCallback(nil)
-			return
+	if asol.isStopped() {
+		return nil
+	}
+
+	var attempt int
+
+	for {
+		attached, err := asol.session(ctx)
+
+		if asol.isStopped() {
+			return nil
 		}
 
-		err := asol.Registered()
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
 
-		if err != nil {
-			asol.OnWebsocketErrorCallback(err)
-			return
+		if _, ok := err.(*wem.NoRegisteredEventError); ok {
+			return err
 		}
 
-		asol.OnOpenCallback()
-		asol.isReady()
-		asol.OnReadyCallback()
-		asol.isLoggedIn()
-		asol.OnLoginCallback()
+		if attached {
+			attempt = 0
 
-		asol.listen()
-	}
+			asol.EmitDisconnect(err)
+		}
 
-	if _, ok := err.(*game.SearchCancelled); ok {
-		asol.OnSearchErrorCallback(err)
+		attempt++
+
+		timer := time.NewTimer(asol.backoff.Delay(attempt))
+
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+
+			if asol.isStopped() {
+				return nil
+			}
+
+			return ctx.Err()
+		}
+
+		if asol.setState(cem.Searching) != nil {
+			return nil
+		}
+
+		asol.EmitReconnect(attempt)
 	}
+}
+
+func (asol *Asol) session(ctx context.Context) (bool, error) {
+	err := asol.run(ctx)
+	attached := asol.isGameRunning()
+
+	asol.setState(cem.Disconnected)
+	asol.setGame(nil)
+	asol.setConnection(nil)
+	asol.setSessionID("")
 
-	if _, ok := err.(*game.ProcessNotFoundError); ok {
-		asol.OnProcessErrorCallback(err)
+	if attached {
+		asol.release()
 	}
 
-	asol.setStatus(false)
-	return
+	return attached, err
 }
 
-func (asol *Asol) Stop() {
-	asol.search.Cancel()
+func (asol *Asol) run(ctx context.Context) error {
+	asol.EmitSearch()
+
+	instance, err := asol.discovery.Discover(ctx)
 
-	if !asol.isGameRunning() {
-		return
+	if err != nil {
+		switch err.(type) {
+		case *game.SearchCancelled:
+			asol.EmitSearchError(err)
+		case *game.ProcessNotFoundError, *game.LockfileNotFoundError, *game.LockfileError:
+			asol.EmitProcessError(err)
+		}
+
+		return err
 	}
 
-	asol.setStatus(false)
+	authorization, err := instance.Authorization()
 
-	message := []interface{}{wem.Unsubscribe, "OnJsonApiEvent"}
-	asol.connection.WriteJSON(&message)
+	if err != nil {
+		asol.EmitProcessError(err)
+		return err
+	}
 
-	_, _, err := asol.connection.ReadMessage()
+	err = asol.setState(cem.Connecting)
 
 	if err != nil {
-		fmt.Errorf("%v", err)
+		return err
 	}
 
-	asol.connection.WriteControl(
-		websocket.CloseMessage,
-		[]byte{},
-		time.Now().Add(time.Second),
-	)
+	asol.client.SetAuthorization(authorization)
+	asol.setGame(instance)
 
-	for {
-		_, _, err := asol.connection.ReadMessage()
+	err = asol.Registered()
+
+	if err != nil {
+		asol.EmitWebsocketError(err)
+		return err
+	}
+
+	asol.EmitOpen()
+
+	err = asol.isReady(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	err = asol.setState(cem.ClientReady)
+
+	if err != nil {
+		return err
+	}
+
+	asol.EmitReady()
+
+	err = asol.isLoggedIn(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	err = asol.setState(cem.LoggedIn)
+
+	if err != nil {
+		return err
+	}
+
+	asol.EmitLogin()
+
+	return asol.listen(ctx)
+}
+
+func (asol *Asol) Stop() {
+	cancel, _, _ := asol.close()
+
+	if cancel != nil {
+		cancel()
+	}
 
-		if websocket.IsCloseError(err, websocket.CloseNormalClosure) {
-			return
+	asol.setGame(nil)
+	asol.setConnection(nil)
+}
+
+// Shutdown waits for the session to end, so it must not be called from a
+// lifecycle callback or a synchronous handler; those should call Stop.
+func (asol *Asol) Shutdown(ctx context.Context) error {
+	cancel, done, connected := asol.close()
+
+	var err error
+
+	if connected && done != nil {
+		select {
+		case <-done:
+		case <-ctx.Done():
+			err = ctx.Err()
 		}
+	}
 
-		if err != nil {
-			fmt.Errorf("%v", err)
-			return
+	if cancel != nil {
+		cancel()
+	}
+
+	if err == nil && done != nil {
+		select {
+		case <-done:
+		case <-ctx.Done():
+			err = ctx.Err()
 		}
 	}
 
 	asol.setGame(nil)
+	asol.setConnection(nil)
+
+	return err
 }
 
-func (asol *Asol) listen() {
+func (asol *Asol) close() (context.CancelFunc, chan struct{}, bool) {
+	asol.setState(cem.Stopped)
+	asol.discovery.Cancel()
+
+	asol.mutex.Lock()
+	var cancel context.CancelFunc = asol.cancel
+	var done chan struct{} = asol.done
+	asol.mutex.Unlock()
+
+	socket := asol.connection()
+
+	if socket == nil {
+		return cancel, done, false
+	}
+
+	asol.unsubscribe()
+
+	socket.send(
+		&outgoing{
+			control: websocket.CloseMessage,
+			data:    websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""),
+		},
+	)
+
+	return cancel, done, true
+}
+
+func (asol *Asol) listen(ctx context.Context) error {
 	dialer := websocket.Dialer{
 		TLSClientConfig: &tls.Config{
 			InsecureSkipVerify: true,
 		},
 	}
 
-	connection, _, err := dialer.Dial(
+	connection, _, err := dialer.DialContext(
+		ctx,
 		asol.client.WebsocketAddress(),
 		http.Header{
 			"Content-Type":  []string{"application/json"},
@@ -249,69 +670,147 @@ func (asol *Asol) listen() {
 	)
 
 	if err != nil {
-		asol.OnWebsocketErrorCallback(
+		asol.EmitWebsocketError(
 			fmt.Errorf("%v", err),
 		)
 
-		return
+		return err
 	}
 
-	asol.connection = connection
+	var keepalive *Keepalive = asol.keepalive
+	socket := newWriter(connection, keepalive)
 
-	asol.mutex.Lock()
-	defer asol.mutex.Unlock()
+	connection.SetPongHandler(func(string) error {
+		keepalive.extend(connection)
+		return nil
+	})
 
-	message := []interface{}{wem.Subscribe, "OnJsonApiEvent"}
-	asol.connection.WriteJSON(&message)
+	asol.setConnection(socket)
+	defer connection.Close()
+	defer asol.calls.Close()
+	defer socket.close()
 
-	_, _, err = asol.connection.ReadMessage()
+	go socket.run(ctx)
+
+	err = asol.subscribe()
 
 	if err != nil {
-		asol.OnWebsocketErrorCallback(
+		asol.EmitWebsocketError(
 			fmt.Errorf("%v", err),
 		)
+
+		return err
+	}
+
+	err = asol.setState(cem.Subscribed)
+
+	if err != nil {
+		return err
+	}
+
+	return asol.read(ctx, socket)
+}
+
+func (asol *Asol) dispatch(message *wem.Message) error {
+	dispatcher := asol.Dispatcher()
+
+	if dispatcher == nil {
+		return asol.Match(message)
 	}
 
-	asol.read()
+	return dispatcher.Dispatch(message)
 }
 
-func (asol *Asol) read() {
-	defer asol.connection.Close()
+func (asol *Asol) read(ctx context.Context, socket *writer) error {
+	var connection *websocket.Conn = socket.connection
+	var failures int
 
 	for {
 		if asol.isRunning() == false {
-			asol.OnWebsocketCloseCallback()
-			break
+			asol.EmitWebsocketClose()
+			return ctx.Err()
 		}
 
-		var response wem.Response
-		err := asol.connection.ReadJSON(&response)
+		socket.keepalive.extend(connection)
+		_, frame, err := connection.ReadMessage()
 
 		if err != nil {
-			if err == io.ErrUnexpectedEOF {
-				continue
+			if ctx.Err() != nil || asol.isStopped() {
+				asol.EmitWebsocketClose()
+				return ctx.Err()
 			}
 
-			asol.OnWebsocketErrorCallback(
+			if timeout, ok := err.(net.Error); ok && timeout.Timeout() {
+				err = &StaleConnectionError{socket.keepalive.ReadTimeout}
+				asol.EmitWebsocketError(err)
+
+				return err
+			}
+
+			asol.EmitWebsocketError(
 				fmt.Errorf("%v", err),
 			)
 
-			asol.setStatus(false)
-			break
+			return err
 		}
 
-		err = asol.Match(
-			&wem.Message{
-				URI:    response.Data["uri"].(string),
-				Method: response.Data["eventType"].(string),
-				Data:   response.Data,
-			},
-		)
+		if recorder := asol.Recorder(); recorder != nil {
+			err = recorder.Record(frame)
+
+			if err != nil {
+				asol.EmitWebsocketError(err)
+			}
+		}
+
+		err = asol.route(frame)
+
+		if err == nil {
+			failures = 0
+			continue
+		}
+
+		asol.EmitWebsocketError(err)
+
+		if _, ok := err.(*wem.MalformedFrameError); !ok {
+			continue
+		}
+
+		failures++
+
+		if failures >= MaximumMalformedFrames {
+			return &TooManyMalformedFramesError{failures, err}
+		}
+	}
+}
+
+func (asol *Asol) route(frame []byte) error {
+	var response wem.Response
+	err := json.Unmarshal(frame, &response)
+
+	if err != nil {
+		if _, ok := err.(*wem.MalformedFrameError); ok {
+			return err
+		}
+
+		return &wem.MalformedFrameError{Frame: string(frame), Reason: err.Error()}
+	}
+
+	switch response.MessageType {
+	case wem.Welcome:
+		asol.setSessionID(response.SessionID)
+		return nil
+	case wem.CallResult, wem.CallError:
+		asol.calls.Resolve(&response)
+		return nil
+	case wem.Event:
+		message, err := response.Message()
 
 		if err != nil {
-			asol.OnWebsocketErrorCallback(
-				fmt.Errorf("%v", err),
-			)
+			return err
 		}
+
+		return asol.dispatch(message)
 	}
+
+	return &wem.UnexpectedFrameError{MessageType: response.MessageType}
 }
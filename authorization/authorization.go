@@ -1,5 +1,11 @@
 package authorization
 
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
 type (
 	Authorization struct {
 		Name     string
@@ -11,4 +17,89 @@ type (
 		Port     string
 		Respawn  string
 	}
+
+	MissingEnvironmentError struct {
+		Variable string
+	}
+
+	InvalidAuthorizationError struct {
+		Field string
+	}
 )
+
+func (error *InvalidAuthorizationError) Error() string {
+	return fmt.Sprintf("The authorization is missing a %s", error.Field)
+}
+
+func (error *MissingEnvironmentError) Error() string {
+	return fmt.Sprintf("%s is not set", error.Variable)
+}
+
+func (authorization *Authorization) Validate() error {
+	if authorization.Port == "" {
+		return &InvalidAuthorizationError{"port"}
+	}
+
+	if authorization.Password == "" {
+		return &InvalidAuthorizationError{"token"}
+	}
+
+	return nil
+}
+
+func FromEnvironment() (*Authorization, error) {
+	authorization := &Authorization{
+		Name:     os.Getenv("ASOL_NAME"),
+		Region:   os.Getenv("ASOL_REGION"),
+		Username: os.Getenv("ASOL_USERNAME"),
+		Password: os.Getenv("ASOL_PASSWORD"),
+		PID:      os.Getenv("ASOL_PID"),
+		Port:     os.Getenv("ASOL_PORT"),
+	}
+
+	if authorization.Port == "" {
+		return nil, &MissingEnvironmentError{"ASOL_PORT"}
+	}
+
+	if authorization.Password == "" {
+		return nil, &MissingEnvironmentError{"ASOL_PASSWORD"}
+	}
+
+	if authorization.Username == "" {
+		authorization.Username = "riot"
+	}
+
+	authorization.App = authorization.Port
+	return authorization, nil
+}
+
+func FromFile(path string) (*Authorization, error) {
+	data, err := os.ReadFile(path)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var authorization Authorization
+	err = json.Unmarshal(data, &authorization)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if authorization.Username == "" {
+		authorization.Username = "riot"
+	}
+
+	if authorization.App == "" {
+		authorization.App = authorization.Port
+	}
+
+	err = authorization.Validate()
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &authorization, nil
+}
@@ -0,0 +1,39 @@
+package asol
+
+import (
+	"time"
+)
+
+type (
+	Backoff struct {
+		Initial    time.Duration
+		Maximum    time.Duration
+		Multiplier float64
+	}
+)
+
+func NewBackoff() *Backoff {
+	return &Backoff{
+		Initial:    1 * time.Second,
+		Maximum:    30 * time.Second,
+		Multiplier: 2,
+	}
+}
+
+func (backoff *Backoff) Delay(attempt int) time.Duration {
+	var delay float64 = float64(backoff.Initial)
+
+	for i := 1; i < attempt; i++ {
+		delay = delay * backoff.Multiplier
+
+		if delay >= float64(backoff.Maximum) {
+			return backoff.Maximum
+		}
+	}
+
+	if delay > float64(backoff.Maximum) {
+		return backoff.Maximum
+	}
+
+	return time.Duration(delay)
+}
@@ -0,0 +1,54 @@
+package asol
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/braycarlson/asol/wem"
+)
+
+const DefaultCallTimeout time.Duration = 10 * time.Second
+
+func (asol *Asol) SetCallTimeout(timeout time.Duration) {
+	asol.callTimeout = timeout
+}
+
+func (asol *Asol) Call(ctx context.Context, procedure string, arguments ...interface{}) (json.RawMessage, error) {
+	if asol.callTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, asol.callTimeout)
+		defer cancel()
+	}
+
+	identifier, pending := asol.calls.Register()
+	defer asol.calls.Forget(identifier)
+
+	message := append([]interface{}{wem.Call, identifier, procedure}, arguments...)
+	err := asol.write(&message)
+
+	if err != nil {
+		return nil, err
+	}
+
+	select {
+	case response, ok := <-pending:
+		if !ok {
+			return nil, &wem.CallClosedError{Procedure: procedure}
+		}
+
+		err = response.Err()
+
+		if err != nil {
+			return nil, err
+		}
+
+		return response.Result, nil
+	case <-ctx.Done():
+		if ctx.Err() == context.DeadlineExceeded {
+			return nil, &wem.CallTimeoutError{Procedure: procedure}
+		}
+
+		return nil, ctx.Err()
+	}
+}
@@ -1,51 +1,269 @@
 package cem
 
+import (
+	"fmt"
+	"reflect"
+	"runtime/debug"
+	"sync"
+)
+
+const (
+	search         string = "search"
+	open           string = "open"
+	ready          string = "ready"
+	login          string = "login"
+	processError   string = "process-error"
+	searchError    string = "search-error"
+	websocketClose string = "websocket-close"
+	websocketError string = "websocket-error"
+	reconnect      string = "reconnect"
+	disconnect     string = "disconnect"
+	stateChange    string = "state-change"
+	panicked       string = "panic"
+	handlerError   string = "handler-error"
+)
+
 type (
 	EventCallback  func()
 	ProcessError   func(error)
 	SearchError    func(error)
 	WebsocketError func(error)
+	Reconnect      func(int)
+	Disconnect     func(error)
+	StateChange    func(State, State)
+	Panic          func(error)
+	HandlerError   func(error)
 
 	ConnectionEventManager struct {
-		OnSearchCallback         EventCallback
-		OnOpenCallback           EventCallback
-		OnReadyCallback          EventCallback
-		OnLoginCallback          EventCallback
-		OnProcessErrorCallback   ProcessError
-		OnSearchErrorCallback    SearchError
-		OnWebsocketCloseCallback EventCallback
-		OnWebsocketErrorCallback WebsocketError
+		listeners  map[string][]*listener
+		identifier int
+		mutex      *sync.Mutex
+	}
+
+	Subscription struct {
+		manager    *ConnectionEventManager
+		event      string
+		identifier int
+	}
+
+	PanicError struct {
+		Event string
+		Value interface{}
+		Stack []byte
+	}
+
+	listener struct {
+		identifier int
+		callback   interface{}
 	}
 )
 
-func (cem *ConnectionEventManager) OnSearch(callback EventCallback) {
-	cem.OnSearchCallback = callback
+func NewConnectionEventManager() *ConnectionEventManager {
+	return &ConnectionEventManager{
+		listeners: make(map[string][]*listener),
+		mutex:     &sync.Mutex{},
+	}
+}
+
+func (error *PanicError) Error() string {
+	return fmt.Sprintf("The %s listener panicked: %v", error.Event, error.Value)
+}
+
+func (subscription *Subscription) Unsubscribe() {
+	subscription.manager.remove(subscription.event, subscription.identifier)
+}
+
+func (cem *ConnectionEventManager) add(event string, callback interface{}) *Subscription {
+	if callback == nil || reflect.ValueOf(callback).IsNil() {
+		return &Subscription{cem, event, 0}
+	}
+
+	cem.mutex.Lock()
+	defer cem.mutex.Unlock()
+
+	cem.identifier++
+
+	cem.listeners[event] = append(
+		cem.listeners[event],
+		&listener{cem.identifier, callback},
+	)
+
+	return &Subscription{cem, event, cem.identifier}
+}
+
+func (cem *ConnectionEventManager) remove(event string, identifier int) {
+	cem.mutex.Lock()
+	defer cem.mutex.Unlock()
+
+	listeners := cem.listeners[event]
+
+	for index, candidate := range listeners {
+		if candidate.identifier == identifier {
+			updated := make([]*listener, 0, len(listeners)-1)
+			updated = append(updated, listeners[:index]...)
+			updated = append(updated, listeners[index+1:]...)
+
+			cem.listeners[event] = updated
+			return
+		}
+	}
+}
+
+func (cem *ConnectionEventManager) snapshot(event string) []*listener {
+	cem.mutex.Lock()
+	defer cem.mutex.Unlock()
+
+	return cem.listeners[event]
+}
+
+func (cem *ConnectionEventManager) emit(event string, call func(interface{})) {
+	for _, listener := range cem.snapshot(event) {
+		cem.invoke(event, listener.callback, call)
+	}
+}
+
+func (cem *ConnectionEventManager) invoke(event string, callback interface{}, call func(interface{})) {
+	defer func() {
+		recovered := recover()
+
+		if recovered == nil || event == panicked {
+			return
+		}
+
+		cem.EmitPanic(
+			&PanicError{event, recovered, debug.Stack()},
+		)
+	}()
+
+	call(callback)
+}
+
+func (cem *ConnectionEventManager) OnSearch(callback EventCallback) *Subscription {
+	return cem.add(search, callback)
+}
+
+func (cem *ConnectionEventManager) OnOpen(callback EventCallback) *Subscription {
+	return cem.add(open, callback)
+}
+
+func (cem *ConnectionEventManager) OnReady(callback EventCallback) *Subscription {
+	return cem.add(ready, callback)
+}
+
+func (cem *ConnectionEventManager) OnLogin(callback EventCallback) *Subscription {
+	return cem.add(login, callback)
+}
+
+func (cem *ConnectionEventManager) OnProcessError(callback ProcessError) *Subscription {
+	return cem.add(processError, callback)
+}
+
+func (cem *ConnectionEventManager) OnSearchError(callback SearchError) *Subscription {
+	return cem.add(searchError, callback)
+}
+
+func (cem *ConnectionEventManager) OnWebsocketClose(callback EventCallback) *Subscription {
+	return cem.add(websocketClose, callback)
+}
+
+func (cem *ConnectionEventManager) OnWebsocketError(callback WebsocketError) *Subscription {
+	return cem.add(websocketError, callback)
+}
+
+func (cem *ConnectionEventManager) OnReconnect(callback Reconnect) *Subscription {
+	return cem.add(reconnect, callback)
+}
+
+func (cem *ConnectionEventManager) OnDisconnect(callback Disconnect) *Subscription {
+	return cem.add(disconnect, callback)
+}
+
+func (cem *ConnectionEventManager) OnStateChange(callback StateChange) *Subscription {
+	return cem.add(stateChange, callback)
+}
+
+func (cem *ConnectionEventManager) OnPanic(callback Panic) *Subscription {
+	return cem.add(panicked, callback)
+}
+
+func (cem *ConnectionEventManager) OnHandlerError(callback HandlerError) *Subscription {
+	return cem.add(handlerError, callback)
+}
+
+func (cem *ConnectionEventManager) EmitSearch() {
+	cem.emit(search, func(callback interface{}) {
+		callback.(EventCallback)()
+	})
+}
+
+func (cem *ConnectionEventManager) EmitOpen() {
+	cem.emit(open, func(callback interface{}) {
+		callback.(EventCallback)()
+	})
+}
+
+func (cem *ConnectionEventManager) EmitReady() {
+	cem.emit(ready, func(callback interface{}) {
+		callback.(EventCallback)()
+	})
+}
+
+func (cem *ConnectionEventManager) EmitLogin() {
+	cem.emit(login, func(callback interface{}) {
+		callback.(EventCallback)()
+	})
+}
+
+func (cem *ConnectionEventManager) EmitProcessError(err error) {
+	cem.emit(processError, func(callback interface{}) {
+		callback.(ProcessError)(err)
+	})
+}
+
+func (cem *ConnectionEventManager) EmitSearchError(err error) {
+	cem.emit(searchError, func(callback interface{}) {
+		callback.(SearchError)(err)
+	})
 }
 
-func (cem *ConnectionEventManager) OnOpen(callback EventCallback) {
-	cem.OnOpenCallback = callback
+func (cem *ConnectionEventManager) EmitWebsocketClose() {
+	cem.emit(websocketClose, func(callback interface{}) {
+		callback.(EventCallback)()
+	})
 }
 
-func (cem *ConnectionEventManager) OnReady(callback EventCallback) {
-	cem.OnReadyCallback = callback
+func (cem *ConnectionEventManager) EmitWebsocketError(err error) {
+	cem.emit(websocketError, func(callback interface{}) {
+		callback.(WebsocketError)(err)
+	})
 }
 
-func (cem *ConnectionEventManager) OnLogin(callback EventCallback) {
-	cem.OnLoginCallback = callback
+func (cem *ConnectionEventManager) EmitReconnect(attempt int) {
+	cem.emit(reconnect, func(callback interface{}) {
+		callback.(Reconnect)(attempt)
+	})
 }
 
-func (cem *ConnectionEventManager) OnProcessError(callback ProcessError) {
-	cem.OnProcessErrorCallback = callback
+func (cem *ConnectionEventManager) EmitDisconnect(err error) {
+	cem.emit(disconnect, func(callback interface{}) {
+		callback.(Disconnect)(err)
+	})
 }
 
-func (cem *ConnectionEventManager) OnSearchError(callback SearchError) {
-	cem.OnSearchErrorCallback = callback
+func (cem *ConnectionEventManager) EmitStateChange(previous State, current State) {
+	cem.emit(stateChange, func(callback interface{}) {
+		callback.(StateChange)(previous, current)
+	})
 }
 
-func (cem *ConnectionEventManager) OnWebsocketClose(callback EventCallback) {
-	cem.OnWebsocketCloseCallback = callback
+func (cem *ConnectionEventManager) EmitPanic(err error) {
+	cem.emit(panicked, func(callback interface{}) {
+		callback.(Panic)(err)
+	})
 }
 
-func (cem *ConnectionEventManager) OnWebsocketError(callback WebsocketError) {
-	cem.OnWebsocketErrorCallback = callback
+func (cem *ConnectionEventManager) EmitHandlerError(err error) {
+	cem.emit(handlerError, func(callback interface{}) {
+		callback.(HandlerError)(err)
+	})
 }
@@ -0,0 +1,73 @@
+package cem
+
+import (
+	"fmt"
+)
+
+const (
+	Idle State = iota
+	Searching
+	Connecting
+	ClientReady
+	LoggedIn
+	Subscribed
+	Disconnected
+	Stopped
+)
+
+type (
+	State int
+
+	InvalidTransitionError struct {
+		From State
+		To   State
+	}
+)
+
+var transitions = map[State][]State{
+	Idle:         {Searching, Stopped},
+	Searching:    {Connecting, Disconnected, Stopped},
+	Connecting:   {ClientReady, Disconnected, Stopped},
+	ClientReady:  {LoggedIn, Disconnected, Stopped},
+	LoggedIn:     {Subscribed, Disconnected, Stopped},
+	Subscribed:   {Disconnected, Stopped},
+	Disconnected: {Searching, Stopped},
+	Stopped:      {Searching},
+}
+
+func (error *InvalidTransitionError) Error() string {
+	return fmt.Sprintf("The state cannot change from %s to %s", error.From, error.To)
+}
+
+func (state State) String() string {
+	switch state {
+	case Idle:
+		return "Idle"
+	case Searching:
+		return "Searching"
+	case Connecting:
+		return "Connecting"
+	case ClientReady:
+		return "ClientReady"
+	case LoggedIn:
+		return "LoggedIn"
+	case Subscribed:
+		return "Subscribed"
+	case Disconnected:
+		return "Disconnected"
+	case Stopped:
+		return "Stopped"
+	}
+
+	return fmt.Sprintf("State(%d)", int(state))
+}
+
+func (state State) CanTransition(next State) bool {
+	for _, candidate := range transitions[state] {
+		if candidate == next {
+			return true
+		}
+	}
+
+	return false
+}
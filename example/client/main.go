@@ -4,6 +4,7 @@ import (
 	"log"
 
 	"github.com/braycarlson/asol"
+	"github.com/braycarlson/asol/wem"
 )
 
 type Client struct {
@@ -48,6 +49,10 @@ func (client *Client) onWebsocketError(error error) {
 	log.Println(error)
 }
 
+func (client *Client) onHandlerError(error error) {
+	log.Println(error)
+}
+
 func (client *Client) onCollection(message []byte) {
 	log.Println(string(message))
 }
@@ -56,6 +61,10 @@ func (client *Client) onGame(message []byte) {
 	log.Println(string(message))
 }
 
+func (client *Client) onPhase(event wem.TypedEvent[string]) {
+	log.Println(event.Data)
+}
+
 func main() {
 	client := &Client{
 		asol.NewAsol(),
@@ -69,6 +78,7 @@ func main() {
 	client.OnSearchError(client.onSearchError)
 	client.OnWebsocketClose(client.onWebsocketClose)
 	client.OnWebsocketError(client.onWebsocketError)
+	client.OnHandlerError(client.onHandlerError)
 
 	client.OnMessage(
 		"/lol-settings/v1/account/lol-collection-champions",
@@ -82,5 +92,12 @@ func main() {
 		client.onGame,
 	)
 
+	wem.On(
+		client.WebsocketEventManager,
+		"/lol-gameflow/v1/gameflow-phase",
+		wem.Update,
+		client.onPhase,
+	)
+
 	client.Start()
 }
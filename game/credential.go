@@ -0,0 +1,29 @@
+package game
+
+import (
+	"context"
+
+	"github.com/braycarlson/asol/authorization"
+)
+
+type (
+	Credential struct {
+		authorization *authorization.Authorization
+	}
+)
+
+func NewCredential(authorization *authorization.Authorization) *Credential {
+	return &Credential{
+		authorization: authorization,
+	}
+}
+
+func (credential *Credential) Discover(ctx context.Context) (*Game, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	return &Game{authorization: credential.authorization}, nil
+}
+
+func (credential *Credential) Cancel() {}
@@ -0,0 +1,12 @@
+package game
+
+import (
+	"context"
+)
+
+type (
+	Discovery interface {
+		Discover(context.Context) (*Game, error)
+		Cancel()
+	}
+)
@@ -0,0 +1,38 @@
+package game
+
+import (
+	"github.com/shirou/gopsutil/v3/process"
+)
+
+type (
+	Process interface {
+		Name() (string, error)
+		CmdlineSlice() ([]string, error)
+	}
+
+	Finder interface {
+		Processes() ([]Process, error)
+	}
+
+	SystemFinder struct{}
+)
+
+func NewSystemFinder() *SystemFinder {
+	return &SystemFinder{}
+}
+
+func (finder *SystemFinder) Processes() ([]Process, error) {
+	processes, err := process.Processes()
+
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]Process, 0, len(processes))
+
+	for _, process := range processes {
+		result = append(result, process)
+	}
+
+	return result, nil
+}
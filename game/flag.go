@@ -0,0 +1,129 @@
+package game
+
+import (
+	"fmt"
+	"strings"
+)
+
+type (
+	Flag map[string][]string
+
+	FlagError struct {
+		Argument string
+		Reason   string
+	}
+
+	FlagErrors []*FlagError
+)
+
+func (error *FlagError) Error() string {
+	return fmt.Sprintf("%q could not be parsed: %s", error.Argument, error.Reason)
+}
+
+func (errors FlagErrors) Error() string {
+	messages := make([]string, 0, len(errors))
+
+	for _, error := range errors {
+		messages = append(messages, error.Error())
+	}
+
+	return strings.Join(messages, "; ")
+}
+
+func ParseFlag(arguments []string) (Flag, error) {
+	flag := make(Flag)
+
+	var errors FlagErrors
+
+	for _, argument := range arguments {
+		var original string = argument
+		argument, ok := unquote(argument)
+
+		if !ok {
+			errors = append(errors, &FlagError{original, "unterminated quote"})
+			continue
+		}
+
+		if !strings.HasPrefix(argument, "--") {
+			continue
+		}
+
+		argument = argument[2:]
+
+		var key string = argument
+		var value string = "true"
+
+		if index := strings.Index(argument, "="); index != -1 {
+			key = argument[:index]
+			value = argument[index+1:]
+		}
+
+		if key == "" {
+			errors = append(errors, &FlagError{original, "missing flag name"})
+			continue
+		}
+
+		flag[key] = append(flag[key], value)
+	}
+
+	if len(errors) > 0 {
+		return flag, errors
+	}
+
+	return flag, nil
+}
+
+// Windows command-line rules: backslashes only escape a following quote.
+func unquote(value string) (string, bool) {
+	if !strings.Contains(value, `"`) {
+		return value, true
+	}
+
+	var builder strings.Builder
+	var backslashes int
+	var quoted bool
+
+	for _, character := range value {
+		switch character {
+		case '\\':
+			backslashes++
+			continue
+		case '"':
+			builder.WriteString(strings.Repeat(`\`, backslashes/2))
+
+			if backslashes%2 == 1 {
+				builder.WriteRune('"')
+			} else {
+				quoted = !quoted
+			}
+		default:
+			builder.WriteString(strings.Repeat(`\`, backslashes))
+			builder.WriteRune(character)
+		}
+
+		backslashes = 0
+	}
+
+	builder.WriteString(strings.Repeat(`\`, backslashes))
+
+	return builder.String(), !quoted
+}
+
+func (flag Flag) Has(key string) bool {
+	_, ok := flag[key]
+	return ok
+}
+
+func (flag Flag) Get(key string) string {
+	values := flag[key]
+
+	if len(values) == 0 {
+		return ""
+	}
+
+	return values[len(values)-1]
+}
+
+func (flag Flag) Values(key string) []string {
+	return flag[key]
+}
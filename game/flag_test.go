@@ -0,0 +1,231 @@
+package game
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseFlag(t *testing.T) {
+	tests := []struct {
+		name      string
+		arguments []string
+		expected  Flag
+		malformed []string
+	}{
+		{
+			name: "windows",
+			arguments: []string{
+				`"C:/Riot Games/League of Legends/LeagueClient/LeagueClientUx.exe"`,
+				`"--riotclient-auth-token=Zk9hQ2xR"`,
+				`"--riotclient-app-port=53287"`,
+				`"--no-rads"`,
+				`"--disable-self-update"`,
+				`"--region=NA"`,
+				`"--locale=en_US"`,
+				`"--remoting-auth-token=q1w2e3r4t5y6"`,
+				`"--respawn-command=LeagueClient.exe"`,
+				`"--respawn-display-name=League of Legends"`,
+				`"--app-port=61442"`,
+				`"--install-directory=D:\LoL"`,
+				`"--app-name=LeagueClient"`,
+				`"--ux-name=LeagueClientUx"`,
+				`"--ux-helper-name=LeagueClientUxHelper"`,
+				`"--log-dir=LeagueClient Logs"`,
+				`"--crash-reporting=crashpad"`,
+				`"--crash-environment=NA1"`,
+				`"--app-log-file-path=C:/Riot Games/League of Legends/Logs/LeagueClient Logs/2023-04-01T12-00-00_1234_LeagueClient.log"`,
+				`"--app-pid=1234"`,
+				`"--output-base-dir=C:/Riot Games/League of Legends"`,
+				`"--no-proxy-server"`,
+				`"--ignore-certificate-errors"`,
+			},
+			expected: Flag{
+				"riotclient-auth-token":     {"Zk9hQ2xR"},
+				"riotclient-app-port":       {"53287"},
+				"no-rads":                   {"true"},
+				"disable-self-update":       {"true"},
+				"region":                    {"NA"},
+				"locale":                    {"en_US"},
+				"remoting-auth-token":       {"q1w2e3r4t5y6"},
+				"respawn-command":           {"LeagueClient.exe"},
+				"respawn-display-name":      {"League of Legends"},
+				"app-port":                  {"61442"},
+				"install-directory":         {`D:\LoL`},
+				"app-name":                  {"LeagueClient"},
+				"ux-name":                   {"LeagueClientUx"},
+				"ux-helper-name":            {"LeagueClientUxHelper"},
+				"log-dir":                   {"LeagueClient Logs"},
+				"crash-reporting":           {"crashpad"},
+				"crash-environment":         {"NA1"},
+				"app-log-file-path":         {"C:/Riot Games/League of Legends/Logs/LeagueClient Logs/2023-04-01T12-00-00_1234_LeagueClient.log"},
+				"app-pid":                   {"1234"},
+				"output-base-dir":           {"C:/Riot Games/League of Legends"},
+				"no-proxy-server":           {"true"},
+				"ignore-certificate-errors": {"true"},
+			},
+		},
+		{
+			name: "windows split on spaces",
+			arguments: []string{
+				`"C:/Riot`,
+				`Games/League`,
+				`of`,
+				`Legends/LeagueClient/LeagueClientUx.exe"`,
+				`"--remoting-auth-token=q1w2e3r4t5y6"`,
+				`"--respawn-display-name=League`,
+				`of`,
+				`Legends"`,
+				`"--app-port=61442"`,
+				`"--install-directory=C:\Riot`,
+				`Games\League`,
+				`of`,
+				`Legends"`,
+			},
+			expected: Flag{
+				"remoting-auth-token": {"q1w2e3r4t5y6"},
+				"app-port":            {"61442"},
+			},
+			malformed: []string{
+				`"C:/Riot`,
+				`Legends/LeagueClient/LeagueClientUx.exe"`,
+				`"--respawn-display-name=League`,
+				`Legends"`,
+				`"--install-directory=C:\Riot`,
+				`Legends"`,
+			},
+		},
+		{
+			name: "macos",
+			arguments: []string{
+				"/Applications/League of Legends.app/Contents/LoL/League of Legends.app/Contents/MacOS/LeagueClientUx",
+				"--riotclient-auth-token=Zk9hQ2xR",
+				"--riotclient-app-port=53287",
+				"--region=EUW",
+				"--locale=en_GB",
+				"--remoting-auth-token=q1w2e3r4t5y6",
+				"--app-port=61442",
+				"--install-directory=/Applications/League of Legends.app/Contents/LoL",
+				"--app-pid=1234",
+			},
+			expected: Flag{
+				"riotclient-auth-token": {"Zk9hQ2xR"},
+				"riotclient-app-port":   {"53287"},
+				"region":                {"EUW"},
+				"locale":                {"en_GB"},
+				"remoting-auth-token":   {"q1w2e3r4t5y6"},
+				"app-port":              {"61442"},
+				"install-directory":     {"/Applications/League of Legends.app/Contents/LoL"},
+				"app-pid":               {"1234"},
+			},
+		},
+		{
+			name: "escaped quotes",
+			arguments: []string{
+				`"--respawn-command=\"C:\Riot Games\LeagueClient.exe\""`,
+				`--install-directory="C:\Riot Games\\"`,
+				`--app-port=61442`,
+			},
+			expected: Flag{
+				"respawn-command":   {`"C:\Riot Games\LeagueClient.exe"`},
+				"install-directory": {`C:\Riot Games\`},
+				"app-port":          {"61442"},
+			},
+		},
+		{
+			name: "malformed arguments are reported",
+			arguments: []string{
+				"--",
+				`"--"`,
+				"--=value",
+				`"`,
+				`"--unterminated`,
+				"-single",
+				"positional",
+				"--app-port=61442",
+				"--remoting-auth-token=q1w2e3r4t5y6",
+			},
+			expected: Flag{
+				"app-port":            {"61442"},
+				"remoting-auth-token": {"q1w2e3r4t5y6"},
+			},
+			malformed: []string{
+				"--",
+				`"--"`,
+				"--=value",
+				`"`,
+				`"--unterminated`,
+			},
+		},
+		{
+			name: "repeated flags",
+			arguments: []string{
+				"--app-port=1",
+				"--app-port=61442",
+			},
+			expected: Flag{
+				"app-port": {"1", "61442"},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			flag, err := ParseFlag(test.arguments)
+
+			if !reflect.DeepEqual(flag, test.expected) {
+				t.Errorf("ParseFlag() = %v, expected %v", flag, test.expected)
+			}
+
+			if test.malformed == nil {
+				if err != nil {
+					t.Errorf("ParseFlag() returned an error: %v", err)
+				}
+
+				return
+			}
+
+			errors, ok := err.(FlagErrors)
+
+			if !ok {
+				t.Fatalf("ParseFlag() error = %v, expected FlagErrors", err)
+			}
+
+			var malformed []string
+
+			for _, error := range errors {
+				malformed = append(malformed, error.Argument)
+			}
+
+			if !reflect.DeepEqual(malformed, test.malformed) {
+				t.Errorf("ParseFlag() malformed = %q, expected %q", malformed, test.malformed)
+			}
+		})
+	}
+}
+
+func TestGameAuthorization(t *testing.T) {
+	game := NewGame(
+		NewMemoryProcess(
+			"LeagueClientUx.exe",
+			[]string{
+				`"C:/Riot Games/League of Legends/LeagueClient/LeagueClientUx.exe"`,
+				`"--"`,
+				`"--remoting-auth-token=q1w2e3r4t5y6"`,
+				`"--install-directory=D:\LoL"`,
+				`"--app-port=61442"`,
+				`"--app-pid=1234"`,
+				`"--region=NA"`,
+			}...,
+		),
+	)
+
+	authorization, err := game.Authorization()
+
+	if err != nil {
+		t.Fatalf("Authorization() returned an error: %v", err)
+	}
+
+	if authorization.Port != "61442" || authorization.Password != "q1w2e3r4t5y6" {
+		t.Errorf("Authorization() = %+v", authorization)
+	}
+}
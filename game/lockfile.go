@@ -0,0 +1,128 @@
+package game
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/braycarlson/asol/authorization"
+)
+
+const DefaultLockfile string = "C:/Riot Games/League of Legends/lockfile"
+
+type (
+	Lockfile struct {
+		path     string
+		cancel   chan struct{}
+		timeout  time.Duration
+		interval time.Duration
+	}
+
+	LockfileNotFoundError struct {
+		Path string
+	}
+
+	LockfileError struct {
+		Path    string
+		Content string
+	}
+)
+
+func NewLockfile(path string) *Lockfile {
+	if path == "" {
+		path = DefaultLockfile
+	}
+
+	return &Lockfile{
+		path:     path,
+		cancel:   make(chan struct{}, 1),
+		timeout:  DefaultTimeout,
+		interval: DefaultInterval,
+	}
+}
+
+func (error *LockfileNotFoundError) Error() string {
+	return fmt.Sprintf("%s could not be found", error.Path)
+}
+
+func (error *LockfileError) Error() string {
+	return fmt.Sprintf("%s is malformed: %q", error.Path, error.Content)
+}
+
+func (lockfile *Lockfile) Path() string {
+	return lockfile.path
+}
+
+func (lockfile *Lockfile) SetTimeout(timeout time.Duration) {
+	lockfile.timeout = timeout
+}
+
+func (lockfile *Lockfile) SetInterval(interval time.Duration) {
+	lockfile.interval = interval
+}
+
+func (lockfile *Lockfile) Cancel() {
+	select {
+	case lockfile.cancel <- struct{}{}:
+	default:
+	}
+}
+
+func (lockfile *Lockfile) Read() (*authorization.Authorization, error) {
+	data, err := os.ReadFile(lockfile.path)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var content string = strings.TrimSpace(string(data))
+	field := strings.Split(content, ":")
+
+	if len(field) != 5 || field[2] == "" || field[3] == "" {
+		return nil, &LockfileError{lockfile.path, content}
+	}
+
+	return &authorization.Authorization{
+		Username: "riot",
+		Password: field[3],
+		Name:     field[0],
+		App:      field[2],
+		PID:      field[1],
+		Port:     field[2],
+	}, nil
+}
+
+func (lockfile *Lockfile) Discover(ctx context.Context) (*Game, error) {
+	select {
+	case <-lockfile.cancel:
+	default:
+	}
+
+	timeout := time.After(lockfile.timeout)
+	ticker := time.NewTicker(lockfile.interval)
+	defer ticker.Stop()
+
+	for {
+		authorization, err := lockfile.Read()
+
+		if err == nil {
+			return &Game{authorization: authorization}, nil
+		}
+
+		select {
+		case <-ticker.C:
+		case <-timeout:
+			if _, ok := err.(*LockfileError); ok {
+				return nil, err
+			}
+
+			return nil, &LockfileNotFoundError{lockfile.path}
+		case <-lockfile.cancel:
+			return nil, &SearchCancelled{}
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
@@ -0,0 +1,72 @@
+package game
+
+import (
+	"sync"
+)
+
+type (
+	MemoryProcess struct {
+		name      string
+		arguments []string
+	}
+
+	MemoryFinder struct {
+		processes []Process
+		mutex     *sync.Mutex
+	}
+)
+
+func NewMemoryProcess(name string, arguments ...string) *MemoryProcess {
+	return &MemoryProcess{
+		name:      name,
+		arguments: arguments,
+	}
+}
+
+func (process *MemoryProcess) Name() (string, error) {
+	return process.name, nil
+}
+
+func (process *MemoryProcess) CmdlineSlice() ([]string, error) {
+	return process.arguments, nil
+}
+
+func NewMemoryFinder(processes ...Process) *MemoryFinder {
+	return &MemoryFinder{
+		processes: processes,
+		mutex:     &sync.Mutex{},
+	}
+}
+
+func (finder *MemoryFinder) Add(process Process) {
+	finder.mutex.Lock()
+	defer finder.mutex.Unlock()
+
+	finder.processes = append(finder.processes, process)
+}
+
+func (finder *MemoryFinder) Remove(process Process) {
+	finder.mutex.Lock()
+	defer finder.mutex.Unlock()
+
+	for index, candidate := range finder.processes {
+		if candidate == process {
+			finder.processes = append(
+				finder.processes[:index],
+				finder.processes[index+1:]...,
+			)
+
+			return
+		}
+	}
+}
+
+func (finder *MemoryFinder) Processes() ([]Process, error) {
+	finder.mutex.Lock()
+	defer finder.mutex.Unlock()
+
+	processes := make([]Process, len(finder.processes))
+	copy(processes, finder.processes)
+
+	return processes, nil
+}
@@ -2,16 +2,14 @@ package game
 
 import (
 	"fmt"
-	"strconv"
-	"strings"
 
 	"github.com/braycarlson/asol/authorization"
-	"github.com/shirou/gopsutil/v3/process"
 )
 
 type (
 	Game struct {
-		process *process.Process
+		process       Process
+		authorization *authorization.Authorization
 	}
 
 	ProcessNotFoundError struct {
@@ -19,58 +17,61 @@ type (
 	}
 )
 
-func NewGame(process *process.Process) *Game {
+func NewGame(process Process) *Game {
 	return &Game{
 		process: process,
 	}
 }
 
-func (game *Game) Authorization() *authorization.Authorization {
-	flag := game.Flag()
-
-	return &authorization.Authorization{
-		Username: flag["username"],
-		Password: flag["remoting-auth-token"],
-		Name:     flag["ux-name"],
-		App:      flag["app-port"],
-		Region:   flag["region"],
-		PID:      flag["app-pid"],
-		Port:     flag["app-port"],
-		Respawn:  flag["respawn-command"],
+func (game *Game) Authorization() (*authorization.Authorization, error) {
+	if game.authorization != nil {
+		return game.authorization, game.authorization.Validate()
 	}
+
+	flag, err := game.Flag()
+
+	if _, ok := err.(FlagErrors); err != nil && !ok {
+		return nil, err
+	}
+
+	authorization := &authorization.Authorization{
+		Username: "riot",
+		Password: flag.Get("remoting-auth-token"),
+		Name:     flag.Get("ux-name"),
+		App:      flag.Get("app-port"),
+		Region:   flag.Get("region"),
+		PID:      flag.Get("app-pid"),
+		Port:     flag.Get("app-port"),
+		Respawn:  flag.Get("respawn-command"),
+	}
+
+	err = authorization.Validate()
+
+	if err != nil {
+		return nil, err
+	}
+
+	return authorization, nil
 }
 
 func (error *ProcessNotFoundError) Error() string {
 	return fmt.Sprintf("%s could not be found", error.Process)
 }
 
-func (game *Game) Process() *process.Process {
+func (game *Game) Process() Process {
 	return game.process
 }
 
-func (game *Game) Flag() map[string]string {
-	arguments, err := game.process.CmdlineSlice()
-
-	if err != nil {
-		return nil
+func (game *Game) Flag() (Flag, error) {
+	if game.process == nil {
+		return Flag{}, nil
 	}
 
-	flags := make(map[string]string)
-
-	for _, argument := range arguments {
-		argument, err = strconv.Unquote(argument)
-		flag := strings.Split(argument, "=")
-
-		if len(flag) == 1 {
-			continue
-		}
-
-		key := flag[0][2:]
-		value := flag[1]
+	arguments, err := game.process.CmdlineSlice()
 
-		flags[key] = value
+	if err != nil {
+		return nil, err
 	}
 
-	flags["username"] = "riot"
-	return flags
+	return ParseFlag(arguments)
 }
@@ -1,14 +1,27 @@
 package game
 
 import (
+	"context"
+	"sync"
 	"time"
+)
 
-	"github.com/shirou/gopsutil/v3/process"
+const (
+	DefaultApplication string        = "LeagueClientUx.exe"
+	DefaultTimeout     time.Duration = 30 * time.Second
+	DefaultInterval    time.Duration = 1 * time.Second
 )
 
 type (
 	Search struct {
-		cancel chan struct{}
+		cancel      chan struct{}
+		closed      chan struct{}
+		once        *sync.Once
+		finder      Finder
+		application string
+		timeout     time.Duration
+		interval    time.Duration
+		selector    Selector
 	}
 
 	SearchCancelled struct {
@@ -18,7 +31,13 @@ type (
 
 func NewSearch() *Search {
 	return &Search{
-		cancel: make(chan struct{}, 1),
+		cancel:      make(chan struct{}, 1),
+		closed:      make(chan struct{}),
+		once:        &sync.Once{},
+		finder:      NewSystemFinder(),
+		application: DefaultApplication,
+		timeout:     DefaultTimeout,
+		interval:    DefaultInterval,
 	}
 }
 
@@ -26,39 +45,115 @@ func (error *SearchCancelled) Error() string {
 	return "The search was cancelled"
 }
 
+func (search *Search) SetFinder(finder Finder) {
+	search.finder = finder
+}
+
+func (search *Search) SetApplication(application string) {
+	search.application = application
+}
+
+func (search *Search) SetTimeout(timeout time.Duration) {
+	search.timeout = timeout
+}
+
+func (search *Search) SetInterval(interval time.Duration) {
+	search.interval = interval
+}
+
+func (search *Search) SetSelector(selector Selector) {
+	search.selector = selector
+}
+
 func (search *Search) Cancel() {
-	search.cancel <- struct{}{}
+	select {
+	case search.cancel <- struct{}{}:
+	default:
+	}
 }
 
 func (search *Search) Close() {
-	close(search.cancel)
+	search.once.Do(func() {
+		close(search.closed)
+	})
+}
+
+func (search *Search) Discover(ctx context.Context) (*Game, error) {
+	process, err := search.Start(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return NewGame(process), nil
 }
 
-func (search *Search) Start() (*process.Process, error) {
-	var application string = "LeagueClientUx.exe"
+func (search *Search) Clients() ([]*Game, error) {
+	processes, err := search.finder.Processes()
+
+	if err != nil {
+		return nil, err
+	}
 
-	timeout := time.After(30 * time.Second)
-	ticker := time.Tick(1 * time.Second)
+	var clients []*Game
+
+	for _, process := range processes {
+		name, _ := process.Name()
+
+		if name != search.application {
+			continue
+		}
+
+		clients = append(clients, NewGame(process))
+	}
+
+	return clients, nil
+}
+
+func (search *Search) find() Process {
+	clients, _ := search.Clients()
+
+	for _, client := range clients {
+		authorization, err := client.Authorization()
+
+		if err != nil {
+			continue
+		}
+
+		if search.selector == nil || search.selector(authorization) {
+			return client.Process()
+		}
+	}
+
+	return nil
+}
+
+func (search *Search) Start(ctx context.Context) (Process, error) {
+	select {
+	case <-search.cancel:
+	default:
+	}
+
+	timeout := time.After(search.timeout)
+	ticker := time.NewTicker(search.interval)
+	defer ticker.Stop()
 
 	for {
 		select {
-		case <-ticker:
-			processes, _ := process.Processes()
-
-			for _, process := range processes {
-				name, _ := process.Name()
+		case <-ticker.C:
+			process := search.find()
 
-				if name == application {
-					return process, nil
-				}
+			if process != nil {
+				return process, nil
 			}
 		case <-timeout:
-			return nil, &ProcessNotFoundError{application}
+			return nil, &ProcessNotFoundError{search.application}
 		case <-search.cancel:
-			search.Close()
 			return nil, &SearchCancelled{}
+		case <-search.closed:
+			return nil, &SearchCancelled{}
+		case <-ctx.Done():
+			return nil, ctx.Err()
 		}
 	}
-
-	return nil, &ProcessNotFoundError{application}
 }
@@ -0,0 +1,221 @@
+package game
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func client(name string, port string, region string) *MemoryProcess {
+	return NewMemoryProcess(
+		name,
+		`"C:/Riot Games/League of Legends/LeagueClient/LeagueClientUx.exe"`,
+		`"--remoting-auth-token=q1w2e3r4t5y6"`,
+		`"--app-port=`+port+`"`,
+		`"--app-pid=`+port+`"`,
+		`"--region=`+region+`"`,
+	)
+}
+
+func search(finder Finder) *Search {
+	search := NewSearch()
+	search.SetFinder(finder)
+	search.SetTimeout(time.Second)
+	search.SetInterval(time.Millisecond)
+
+	return search
+}
+
+func port(t *testing.T, process Process) string {
+	authorization, err := NewGame(process).Authorization()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return authorization.Port
+}
+
+func TestSearchApplication(t *testing.T) {
+	finder := NewMemoryFinder(
+		client("LeagueClient.exe", "1", "NA"),
+		client(DefaultApplication, "2", "NA"),
+	)
+
+	process, err := search(finder).Start(context.Background())
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if port := port(t, process); port != "2" {
+		t.Errorf("Start() found port %s, expected 2", port)
+	}
+
+	search := search(finder)
+	search.SetApplication("LeagueClient.exe")
+
+	process, err = search.Start(context.Background())
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if port := port(t, process); port != "1" {
+		t.Errorf("Start() found port %s, expected 1", port)
+	}
+}
+
+func TestSearchSkipsInvalidClients(t *testing.T) {
+	finder := NewMemoryFinder(
+		NewMemoryProcess(DefaultApplication, `"--app-port=1"`),
+		client(DefaultApplication, "2", "NA"),
+	)
+
+	process, err := search(finder).Start(context.Background())
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if port := port(t, process); port != "2" {
+		t.Errorf("Start() found port %s, expected 2", port)
+	}
+}
+
+func TestSearchTimeout(t *testing.T) {
+	search := search(NewMemoryFinder())
+	search.SetTimeout(20 * time.Millisecond)
+
+	start := time.Now()
+	_, err := search.Start(context.Background())
+
+	if _, ok := err.(*ProcessNotFoundError); !ok {
+		t.Fatalf("Start() = %v, expected a ProcessNotFoundError", err)
+	}
+
+	if elapsed := time.Since(start); elapsed < 20*time.Millisecond || elapsed > time.Second {
+		t.Errorf("Start() returned after %v", elapsed)
+	}
+}
+
+func TestSearchInterval(t *testing.T) {
+	finder := NewMemoryFinder()
+	search := search(finder)
+	search.SetInterval(50 * time.Millisecond)
+
+	time.AfterFunc(10*time.Millisecond, func() {
+		finder.Add(client(DefaultApplication, "1", "NA"))
+	})
+
+	start := time.Now()
+	_, err := search.Start(context.Background())
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if elapsed := time.Since(start); elapsed < 50*time.Millisecond {
+		t.Errorf("Start() polled before the interval elapsed: %v", elapsed)
+	}
+}
+
+func TestSearchSelector(t *testing.T) {
+	finder := NewMemoryFinder(
+		client(DefaultApplication, "1", "NA"),
+		client(DefaultApplication, "2", "EUW"),
+		client(DefaultApplication, "3", "EUW"),
+	)
+
+	tests := []struct {
+		name     string
+		selector Selector
+		expected string
+	}{
+		{"none", nil, "1"},
+		{"port", ByPort("3"), "3"},
+		{"pid", ByPID("2"), "2"},
+		{"region", ByRegion("euw"), "2"},
+		{"name", ByName("LeagueClientUx"), ""},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			search := search(finder)
+			search.SetTimeout(20 * time.Millisecond)
+			search.SetSelector(test.selector)
+
+			process, err := search.Start(context.Background())
+
+			if test.expected == "" {
+				if _, ok := err.(*ProcessNotFoundError); !ok {
+					t.Errorf("Start() = %v, expected a ProcessNotFoundError", err)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if port := port(t, process); port != test.expected {
+				t.Errorf("Start() found port %s, expected %s", port, test.expected)
+			}
+		})
+	}
+}
+
+func TestSearchCancel(t *testing.T) {
+	search := search(NewMemoryFinder())
+
+	search.Cancel()
+	search.Cancel()
+
+	time.AfterFunc(10*time.Millisecond, search.Cancel)
+
+	_, err := search.Start(context.Background())
+
+	if _, ok := err.(*SearchCancelled); !ok {
+		t.Fatalf("Start() = %v, expected a SearchCancelled", err)
+	}
+}
+
+func TestSearchClose(t *testing.T) {
+	search := search(NewMemoryFinder())
+
+	search.Close()
+	search.Close()
+	search.Cancel()
+
+	_, err := search.Start(context.Background())
+
+	if _, ok := err.(*SearchCancelled); !ok {
+		t.Fatalf("Start() = %v, expected a SearchCancelled", err)
+	}
+}
+
+func TestSearchContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := search(NewMemoryFinder()).Start(ctx)
+
+	if err != context.Canceled {
+		t.Fatalf("Start() = %v, expected %v", err, context.Canceled)
+	}
+}
+
+func TestSearchDiscover(t *testing.T) {
+	var discovery Discovery = search(NewMemoryFinder(client(DefaultApplication, "1", "NA")))
+
+	game, err := discovery.Discover(context.Background())
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if port := port(t, game.Process()); port != "1" {
+		t.Errorf("Discover() found port %s, expected 1", port)
+	}
+}
@@ -0,0 +1,35 @@
+package game
+
+import (
+	"strings"
+
+	"github.com/braycarlson/asol/authorization"
+)
+
+type (
+	Selector func(*authorization.Authorization) bool
+)
+
+func ByPID(pid string) Selector {
+	return func(authorization *authorization.Authorization) bool {
+		return authorization.PID == pid
+	}
+}
+
+func ByPort(port string) Selector {
+	return func(authorization *authorization.Authorization) bool {
+		return authorization.Port == port
+	}
+}
+
+func ByRegion(region string) Selector {
+	return func(authorization *authorization.Authorization) bool {
+		return strings.EqualFold(authorization.Region, region)
+	}
+}
+
+func ByName(name string) Selector {
+	return func(authorization *authorization.Authorization) bool {
+		return authorization.Name == name
+	}
+}
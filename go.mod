@@ -1,12 +1,15 @@
 module github.com/braycarlson/asol
 
-go 1.17
+go 1.18
+
+require (
+	github.com/gorilla/websocket v1.4.2
+	github.com/shirou/gopsutil/v3 v3.21.7
+)
 
 require (
 	github.com/StackExchange/wmi v1.2.1 // indirect
 	github.com/go-ole/go-ole v1.2.5 // indirect
-	github.com/gorilla/websocket v1.4.2 // indirect
-	github.com/shirou/gopsutil/v3 v3.21.7 // indirect
 	github.com/tklauser/go-sysconf v0.3.7 // indirect
 	github.com/tklauser/numcpus v0.2.3 // indirect
 	golang.org/x/sys v0.0.0-20210630005230-0f9fa26af87c // indirect
@@ -0,0 +1,154 @@
+package asol
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+type (
+	Keepalive struct {
+		PingInterval time.Duration
+		ReadTimeout  time.Duration
+		WriteTimeout time.Duration
+	}
+
+	StaleConnectionError struct {
+		Timeout time.Duration
+	}
+
+	writer struct {
+		connection *websocket.Conn
+		keepalive  *Keepalive
+		queue      chan *outgoing
+		done       chan struct{}
+	}
+
+	outgoing struct {
+		message interface{}
+		control int
+		data    []byte
+		result  chan error
+	}
+)
+
+func NewKeepalive() *Keepalive {
+	return &Keepalive{
+		PingInterval: 15 * time.Second,
+		ReadTimeout:  45 * time.Second,
+		WriteTimeout: 10 * time.Second,
+	}
+}
+
+func (error *StaleConnectionError) Error() string {
+	return fmt.Sprintf("The websocket was silent for %v and is considered stale", error.Timeout)
+}
+
+func (keepalive *Keepalive) deadline(timeout time.Duration) time.Time {
+	if timeout <= 0 {
+		return time.Time{}
+	}
+
+	return time.Now().Add(timeout)
+}
+
+func (keepalive *Keepalive) extend(connection *websocket.Conn) {
+	connection.SetReadDeadline(
+		keepalive.deadline(keepalive.ReadTimeout),
+	)
+}
+
+func newWriter(connection *websocket.Conn, keepalive *Keepalive) *writer {
+	return &writer{
+		connection: connection,
+		keepalive:  keepalive,
+		queue:      make(chan *outgoing),
+		done:       make(chan struct{}),
+	}
+}
+
+func (writer *writer) run(ctx context.Context) {
+	var ticker <-chan time.Time
+
+	if writer.keepalive.PingInterval > 0 {
+		pinger := time.NewTicker(writer.keepalive.PingInterval)
+		defer pinger.Stop()
+
+		ticker = pinger.C
+	}
+
+	for {
+		select {
+		case request := <-writer.queue:
+			request.result <- writer.write(request)
+		case <-ticker:
+			writer.connection.WriteControl(
+				websocket.PingMessage,
+				[]byte{},
+				writer.control(),
+			)
+		case <-ctx.Done():
+			writer.connection.WriteControl(
+				websocket.CloseMessage,
+				websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""),
+				time.Now().Add(time.Second),
+			)
+
+			writer.connection.Close()
+			return
+		case <-writer.done:
+			return
+		}
+	}
+}
+
+func (writer *writer) control() time.Time {
+	if writer.keepalive.WriteTimeout <= 0 {
+		return time.Now().Add(time.Second)
+	}
+
+	return time.Now().Add(writer.keepalive.WriteTimeout)
+}
+
+func (writer *writer) write(request *outgoing) error {
+	if request.control != 0 {
+		return writer.connection.WriteControl(
+			request.control,
+			request.data,
+			writer.control(),
+		)
+	}
+
+	writer.connection.SetWriteDeadline(
+		writer.keepalive.deadline(writer.keepalive.WriteTimeout),
+	)
+
+	return writer.connection.WriteJSON(request.message)
+}
+
+func (writer *writer) send(request *outgoing) error {
+	request.result = make(chan error, 1)
+
+	select {
+	case writer.queue <- request:
+	case <-writer.done:
+		return &NotConnectedError{}
+	}
+
+	select {
+	case err := <-request.result:
+		return err
+	case <-writer.done:
+		return &NotConnectedError{}
+	}
+}
+
+func (writer *writer) close() {
+	close(writer.done)
+}
+
+func (asol *Asol) SetKeepalive(keepalive *Keepalive) {
+	asol.keepalive = keepalive
+}
@@ -3,9 +3,11 @@ package request
 import (
 	"crypto/tls"
 	"encoding/base64"
+	"encoding/json"
 	"fmt"
 	"net"
 	"net/http"
+	"sync"
 	"time"
 
 	"github.com/braycarlson/asol/authorization"
@@ -14,8 +16,10 @@ import (
 type (
 	HTTPClient struct {
 		client        *http.Client
+		insecure      *http.Client
 		authorization *authorization.Authorization
 		strategy      RequestStrategy
+		mutex         *sync.RWMutex
 	}
 
 	ClientError struct {
@@ -23,6 +27,11 @@ type (
 		error   error
 	}
 
+	failure struct {
+		ErrorCode  string `json:"errorCode"`
+		HttpStatus int    `json:"httpStatus"`
+	}
+
 	RequestStrategy interface {
 		Request(*HTTPClient, *http.Request) ([]byte, error)
 		Get(string) (*http.Request, error)
@@ -48,16 +57,26 @@ func NewHTTPClient() *HTTPClient {
 	transport.MaxConnsPerHost = 25
 	transport.MaxIdleConnsPerHost = 25
 	transport.ResponseHeaderTimeout = 5 * time.Second
-	transport.TLSClientConfig = &tls.Config{InsecureSkipVerify: true}
+	transport.TLSClientConfig = &tls.Config{InsecureSkipVerify: false}
 	transport.TLSHandshakeTimeout = 5 * time.Second
 
+	insecure := transport.Clone()
+	insecure.TLSClientConfig = &tls.Config{InsecureSkipVerify: true}
+
+	authorization := &authorization.Authorization{}
+
 	return &HTTPClient{
 		client: &http.Client{
 			Timeout:   time.Second * 10,
 			Transport: transport,
 		},
-		authorization: &authorization.Authorization{},
-		strategy:      &Websocket{},
+		insecure: &http.Client{
+			Timeout:   time.Second * 10,
+			Transport: insecure,
+		},
+		authorization: authorization,
+		strategy:      &Websocket{authorization},
+		mutex:         &sync.RWMutex{},
 	}
 }
 
@@ -65,26 +84,56 @@ func (error *ClientError) Error() string {
 	return fmt.Sprintf("%s: %v", error.message, error.error)
 }
 
+func IsFailure(data []byte) bool {
+	var failure failure
+
+	if json.Unmarshal(data, &failure) != nil {
+		return false
+	}
+
+	return failure.ErrorCode != "" && failure.HttpStatus >= 400
+}
+
 func (client *HTTPClient) SetWeb() {
+	client.mutex.Lock()
+	defer client.mutex.Unlock()
+
 	client.strategy = &Web{}
 }
 
 func (client *HTTPClient) SetWebsocket() {
+	client.mutex.Lock()
+	defer client.mutex.Unlock()
+
 	client.strategy = &Websocket{
 		authorization: client.authorization,
 	}
 }
 
-func (client *HTTPClient) setInsecureSkipVerify(insecureSkipVerify bool) {
-	tlsClientConfig := &tls.Config{InsecureSkipVerify: insecureSkipVerify}
-	client.client.Transport.(*http.Transport).TLSClientConfig = tlsClientConfig
+func (client *HTTPClient) Local() *Websocket {
+	client.mutex.RLock()
+	defer client.mutex.RUnlock()
+
+	return &Websocket{
+		authorization: client.authorization,
+	}
 }
 
 func (client *HTTPClient) SetAuthorization(authorization *authorization.Authorization) {
+	client.mutex.Lock()
+	defer client.mutex.Unlock()
+
 	client.authorization = authorization
+
+	if _, ok := client.strategy.(*Websocket); ok {
+		client.strategy = &Websocket{authorization}
+	}
 }
 
 func (client *HTTPClient) Credential() string {
+	client.mutex.RLock()
+	defer client.mutex.RUnlock()
+
 	username := client.authorization.Username
 	password := client.authorization.Password
 
@@ -94,35 +143,48 @@ func (client *HTTPClient) Credential() string {
 }
 
 func (client *HTTPClient) WebsocketAddress() string {
+	client.mutex.RLock()
+	defer client.mutex.RUnlock()
+
 	port := client.authorization.Port
 	return "wss://127.0.0.1:" + port
 }
 
 func (client *HTTPClient) LocalAddress() string {
+	client.mutex.RLock()
+	defer client.mutex.RUnlock()
+
 	port := client.authorization.Port
 	return "https://127.0.0.1:" + port
 }
 
+func (client *HTTPClient) Strategy() RequestStrategy {
+	client.mutex.RLock()
+	defer client.mutex.RUnlock()
+
+	return client.strategy
+}
+
 func (client *HTTPClient) Get(uri string) (*http.Request, error) {
-	return client.strategy.Get(uri)
+	return client.Strategy().Get(uri)
 }
 
 func (client *HTTPClient) Post(uri string, data []byte) (*http.Request, error) {
-	return client.strategy.Post(uri, data)
+	return client.Strategy().Post(uri, data)
 }
 
 func (client *HTTPClient) Patch(uri string, data []byte) (*http.Request, error) {
-	return client.strategy.Patch(uri, data)
+	return client.Strategy().Patch(uri, data)
 }
 
 func (client *HTTPClient) Put(uri string, data []byte) (*http.Request, error) {
-	return client.strategy.Put(uri, data)
+	return client.Strategy().Put(uri, data)
 }
 
 func (client *HTTPClient) Delete(uri string) (*http.Request, error) {
-	return client.strategy.Delete(uri)
+	return client.Strategy().Delete(uri)
 }
 
 func (client *HTTPClient) Request(request *http.Request) ([]byte, error) {
-	return client.strategy.Request(client, request)
+	return client.Strategy().Request(client, request)
 }
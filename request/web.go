@@ -117,7 +117,6 @@ func (web *Web) Delete(uri string) (*http.Request, error) {
 }
 
 func (web *Web) Request(client *HTTPClient, request *http.Request) ([]byte, error) {
-	client.setInsecureSkipVerify(false)
 	response, err := client.client.Do(request)
 
 	if err != nil {
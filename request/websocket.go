@@ -146,14 +146,12 @@ func (websocket *Websocket) Delete(uri string) (*http.Request, error) {
 }
 
 func (websocket *Websocket) Request(client *HTTPClient, request *http.Request) ([]byte, error) {
-	client.setInsecureSkipVerify(true)
-
 	request.Header.Set(
 		"Authorization",
 		"Basic "+websocket.Credential(),
 	)
 
-	response, err := client.client.Do(request)
+	response, err := client.insecure.Do(request)
 
 	if err != nil {
 		return nil, &ClientError{"WebsocketRequest", err}
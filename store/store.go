@@ -0,0 +1,341 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"github.com/braycarlson/asol"
+	"github.com/braycarlson/asol/cem"
+	"github.com/braycarlson/asol/request"
+	"github.com/braycarlson/asol/wem"
+)
+
+type (
+	ChangeCallback func(Change)
+
+	Store struct {
+		asol         *asol.Asol
+		uris         []string
+		values       map[string]json.RawMessage
+		versions     map[string]uint64
+		generation   uint64
+		handlers     []*wem.Handler
+		subscription *cem.Subscription
+		listeners    map[int]ChangeCallback
+		identifier   int
+		errorHook    func(error)
+		mutex        *sync.RWMutex
+	}
+
+	Change struct {
+		URI      string
+		Method   string
+		Previous json.RawMessage
+		Current  json.RawMessage
+	}
+
+	Subscription struct {
+		store      *Store
+		identifier int
+	}
+
+	NotFoundError struct {
+		URI string
+	}
+
+	LoadError struct {
+		URI   string
+		error error
+	}
+
+	guard struct {
+		generation uint64
+		version    uint64
+	}
+
+	event struct {
+		Data      json.RawMessage `json:"data"`
+		EventType string          `json:"eventType"`
+		URI       string          `json:"uri"`
+	}
+)
+
+func NewStore(asol *asol.Asol, uris ...string) *Store {
+	return &Store{
+		asol:      asol,
+		uris:      uris,
+		values:    make(map[string]json.RawMessage),
+		versions:  make(map[string]uint64),
+		listeners: make(map[int]ChangeCallback),
+		mutex:     &sync.RWMutex{},
+	}
+}
+
+func (error *NotFoundError) Error() string {
+	return fmt.Sprintf("%s is not in the store", error.URI)
+}
+
+func (error *LoadError) Error() string {
+	return fmt.Sprintf("%s could not be loaded: %v", error.URI, error.error)
+}
+
+func (error *LoadError) Unwrap() error {
+	return error.error
+}
+
+func (subscription *Subscription) Unsubscribe() {
+	subscription.store.mutex.Lock()
+	defer subscription.store.mutex.Unlock()
+
+	delete(subscription.store.listeners, subscription.identifier)
+}
+
+func (store *Store) Start(ctx context.Context) error {
+	for _, uri := range store.uris {
+		handler, err := store.asol.Register(
+			wem.Route{
+				Pattern:  uri,
+				Callback: store.onEvent,
+			},
+		)
+
+		if err != nil {
+			store.Close()
+			return err
+		}
+
+		store.handlers = append(store.handlers, handler)
+	}
+
+	store.subscription = store.asol.OnStateChange(func(previous cem.State, current cem.State) {
+		switch current {
+		case cem.Subscribed:
+			go store.Load(ctx)
+		case cem.Disconnected, cem.Stopped:
+			store.clear()
+		}
+	})
+
+	if store.asol.State() == cem.Subscribed {
+		return store.Load(ctx)
+	}
+
+	return nil
+}
+
+func (store *Store) Close() {
+	for _, handler := range store.handlers {
+		handler.Unsubscribe()
+	}
+
+	store.handlers = nil
+
+	if store.subscription != nil {
+		store.subscription.Unsubscribe()
+		store.subscription = nil
+	}
+}
+
+func (store *Store) OnError(hook func(error)) {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	store.errorHook = hook
+}
+
+func (store *Store) fail(err error) {
+	store.mutex.RLock()
+	var hook func(error) = store.errorHook
+	store.mutex.RUnlock()
+
+	if hook != nil {
+		hook(err)
+	}
+}
+
+func (store *Store) Load(ctx context.Context) error {
+	var first error
+
+	for _, uri := range store.uris {
+		if wem.IsPattern(uri) {
+			continue
+		}
+
+		err := store.load(ctx, uri)
+
+		if err == nil {
+			continue
+		}
+
+		err = &LoadError{uri, err}
+		store.fail(err)
+
+		if first == nil {
+			first = err
+		}
+	}
+
+	return first
+}
+
+func (store *Store) load(ctx context.Context, uri string) error {
+	store.mutex.RLock()
+	guard := &guard{store.generation, store.versions[uri]}
+	store.mutex.RUnlock()
+
+	client := store.asol.Client()
+	local := client.Local()
+
+	get, err := local.Get(uri)
+
+	if err != nil {
+		return err
+	}
+
+	data, err := local.Request(client, get.WithContext(ctx))
+
+	if err != nil {
+		return err
+	}
+
+	var method string = wem.Update
+
+	if request.IsFailure(data) {
+		method = wem.Delete
+		data = nil
+	}
+
+	store.apply(uri, method, data, guard)
+	return nil
+}
+
+func (store *Store) onEvent(data []byte, params wem.Params) {
+	var event event
+	err := json.Unmarshal(data, &event)
+
+	if err != nil {
+		return
+	}
+
+	store.apply(event.URI, event.EventType, event.Data, nil)
+}
+
+func (store *Store) apply(uri string, method string, data json.RawMessage, guard *guard) {
+	store.mutex.Lock()
+
+	if guard != nil && (store.generation != guard.generation || store.versions[uri] != guard.version) {
+		store.mutex.Unlock()
+		return
+	}
+
+	previous, existed := store.values[uri]
+
+	if method == wem.Delete {
+		delete(store.values, uri)
+	} else {
+		store.values[uri] = data
+	}
+
+	store.versions[uri]++
+
+	listeners := store.snapshotListeners()
+	store.mutex.Unlock()
+
+	if method == wem.Delete && !existed {
+		return
+	}
+
+	notify(
+		listeners,
+		Change{
+			URI:      uri,
+			Method:   method,
+			Previous: previous,
+			Current:  data,
+		},
+	)
+}
+
+func (store *Store) clear() {
+	store.mutex.Lock()
+	values := store.values
+
+	store.values = make(map[string]json.RawMessage)
+	store.versions = make(map[string]uint64)
+	store.generation++
+
+	listeners := store.snapshotListeners()
+	store.mutex.Unlock()
+
+	for uri, previous := range values {
+		notify(
+			listeners,
+			Change{
+				URI:      uri,
+				Method:   wem.Delete,
+				Previous: previous,
+			},
+		)
+	}
+}
+
+func (store *Store) snapshotListeners() []ChangeCallback {
+	listeners := make([]ChangeCallback, 0, len(store.listeners))
+
+	for _, listener := range store.listeners {
+		listeners = append(listeners, listener)
+	}
+
+	return listeners
+}
+
+func notify(listeners []ChangeCallback, change Change) {
+	for _, listener := range listeners {
+		listener(change)
+	}
+}
+
+func (store *Store) OnChange(callback ChangeCallback) *Subscription {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	store.identifier++
+	store.listeners[store.identifier] = callback
+
+	return &Subscription{store, store.identifier}
+}
+
+func (store *Store) Get(uri string) (json.RawMessage, bool) {
+	store.mutex.RLock()
+	defer store.mutex.RUnlock()
+
+	data, ok := store.values[uri]
+	return data, ok
+}
+
+func (store *Store) Snapshot() map[string]json.RawMessage {
+	store.mutex.RLock()
+	defer store.mutex.RUnlock()
+
+	snapshot := make(map[string]json.RawMessage, len(store.values))
+
+	for uri, data := range store.values {
+		snapshot[uri] = data
+	}
+
+	return snapshot
+}
+
+func Get[T any](store *Store, uri string) (T, error) {
+	var value T
+	data, ok := store.Get(uri)
+
+	if !ok {
+		return value, &NotFoundError{uri}
+	}
+
+	err := json.Unmarshal(data, &value)
+	return value, err
+}
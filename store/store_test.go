@@ -0,0 +1,141 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/braycarlson/asol"
+	"github.com/braycarlson/asol/cem"
+	"github.com/braycarlson/asol/wem"
+)
+
+const uri string = "/lol-gameflow/v1/gameflow-phase"
+
+func recorder(store *Store) *[]Change {
+	changes := &[]Change{}
+
+	store.OnChange(func(change Change) {
+		*changes = append(*changes, change)
+	})
+
+	return changes
+}
+
+func message(method string, data string) []byte {
+	payload, _ := json.Marshal(
+		map[string]interface{}{
+			"uri":       uri,
+			"eventType": method,
+			"data":      json.RawMessage(data),
+		},
+	)
+
+	return payload
+}
+
+func (store *Store) snapshotGuard(uri string) *guard {
+	store.mutex.RLock()
+	defer store.mutex.RUnlock()
+
+	return &guard{store.generation, store.versions[uri]}
+}
+
+func TestLoadApplies(t *testing.T) {
+	store := NewStore(asol.NewAsol(), uri)
+	changes := recorder(store)
+
+	store.apply(uri, wem.Update, json.RawMessage(`"Lobby"`), store.snapshotGuard(uri))
+
+	data, ok := store.Get(uri)
+
+	if !ok || string(data) != `"Lobby"` {
+		t.Fatalf("Get() = %s, %v", data, ok)
+	}
+
+	if len(*changes) != 1 || (*changes)[0].Method != wem.Update {
+		t.Errorf("changes = %+v", *changes)
+	}
+}
+
+func TestLoadLosesToEvent(t *testing.T) {
+	store := NewStore(asol.NewAsol(), uri)
+	changes := recorder(store)
+
+	guard := store.snapshotGuard(uri)
+
+	store.onEvent(message(wem.Update, `"ChampSelect"`), nil)
+	store.apply(uri, wem.Update, json.RawMessage(`"Lobby"`), guard)
+
+	data, _ := store.Get(uri)
+
+	if string(data) != `"ChampSelect"` {
+		t.Errorf("Get() = %s, expected the event to win over the stale load", data)
+	}
+
+	if len(*changes) != 1 {
+		t.Errorf("changes = %+v", *changes)
+	}
+}
+
+func TestLoadFailureDeletes(t *testing.T) {
+	store := NewStore(asol.NewAsol(), uri)
+	changes := recorder(store)
+
+	store.apply(uri, wem.Delete, nil, store.snapshotGuard(uri))
+
+	if len(*changes) != 0 {
+		t.Errorf("a delete of an unknown uri notified %+v", *changes)
+	}
+
+	store.onEvent(message(wem.Create, `"Lobby"`), nil)
+	store.apply(uri, wem.Delete, nil, store.snapshotGuard(uri))
+
+	if _, ok := store.Get(uri); ok {
+		t.Errorf("Get() found a value after the load failed")
+	}
+
+	if len(*changes) != 2 || (*changes)[1].Method != wem.Delete {
+		t.Errorf("changes = %+v", *changes)
+	}
+}
+
+func TestClearDiscardsPreviousSession(t *testing.T) {
+	client := asol.NewAsol()
+	store := NewStore(client, uri)
+
+	err := store.Start(context.Background())
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer store.Close()
+
+	for _, state := range []cem.State{cem.Disconnected, cem.Stopped} {
+		t.Run(state.String(), func(t *testing.T) {
+			store.onEvent(message(wem.Update, `"InProgress"`), nil)
+			changes := recorder(store)
+			guard := store.snapshotGuard(uri)
+
+			client.EmitStateChange(cem.Subscribed, state)
+
+			if snapshot := store.Snapshot(); len(snapshot) != 0 {
+				t.Errorf("Snapshot() = %v after %v", snapshot, state)
+			}
+
+			expected := []Change{{URI: uri, Method: wem.Delete, Previous: json.RawMessage(`"InProgress"`)}}
+
+			if !reflect.DeepEqual(*changes, expected) {
+				t.Errorf("changes = %+v, expected %+v", *changes, expected)
+			}
+
+			store.apply(uri, wem.Update, json.RawMessage(`"Lobby"`), guard)
+
+			if _, ok := store.Get(uri); ok {
+				t.Errorf("a load from the previous session was applied")
+			}
+		})
+	}
+}
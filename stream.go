@@ -0,0 +1,65 @@
+package asol
+
+import (
+	"context"
+
+	"github.com/braycarlson/asol/wem"
+)
+
+func (asol *Asol) Stream(ctx context.Context, pattern string, methods ...string) (<-chan wem.Message, error) {
+	return asol.StreamWith(
+		ctx,
+		pattern,
+		methods,
+		wem.StreamOptions{Buffer: wem.DefaultStreamBuffer, Policy: wem.Drop},
+	)
+}
+
+func (asol *Asol) StreamWith(ctx context.Context, pattern string, methods []string, options wem.StreamOptions) (<-chan wem.Message, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	identifier := asol.track(cancel)
+
+	channel, err := asol.WebsocketEventManager.StreamWith(ctx, pattern, methods, options)
+
+	if err != nil {
+		asol.untrack(identifier)
+		cancel()
+
+		return nil, err
+	}
+
+	go func() {
+		<-ctx.Done()
+		asol.untrack(identifier)
+	}()
+
+	return channel, nil
+}
+
+func (asol *Asol) track(cancel context.CancelFunc) int {
+	asol.mutex.Lock()
+	defer asol.mutex.Unlock()
+
+	asol.identifier++
+	asol.streams[asol.identifier] = cancel
+
+	return asol.identifier
+}
+
+func (asol *Asol) untrack(identifier int) {
+	asol.mutex.Lock()
+	defer asol.mutex.Unlock()
+
+	delete(asol.streams, identifier)
+}
+
+func (asol *Asol) release() {
+	asol.mutex.Lock()
+	streams := asol.streams
+	asol.streams = make(map[int]context.CancelFunc)
+	asol.mutex.Unlock()
+
+	for _, cancel := range streams {
+		cancel()
+	}
+}
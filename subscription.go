@@ -0,0 +1,153 @@
+package asol
+
+import (
+	"sort"
+
+	"github.com/braycarlson/asol/wem"
+)
+
+func (asol *Asol) onRegister(pattern string) {
+	var event string = wem.EventName(pattern)
+
+	asol.update(func() {
+		asol.handlers[event]++
+	})
+}
+
+func (asol *Asol) onUnregister(pattern string) {
+	var event string = wem.EventName(pattern)
+
+	asol.update(func() {
+		asol.handlers[event]--
+
+		if asol.handlers[event] <= 0 {
+			delete(asol.handlers, event)
+		}
+	})
+}
+
+func (asol *Asol) Subscribe(event string) error {
+	return asol.update(func() {
+		asol.events[event] = struct{}{}
+	})
+}
+
+func (asol *Asol) Unsubscribe(event string) error {
+	return asol.update(func() {
+		delete(asol.events, event)
+	})
+}
+
+func (asol *Asol) Subscriptions() []string {
+	asol.mutex.Lock()
+	defer asol.mutex.Unlock()
+
+	return sorted(asol.requested())
+}
+
+func (asol *Asol) requested() map[string]struct{} {
+	events := make(map[string]struct{}, len(asol.events)+len(asol.handlers))
+
+	for event := range asol.events {
+		events[event] = struct{}{}
+	}
+
+	for event := range asol.handlers {
+		events[event] = struct{}{}
+	}
+
+	return events
+}
+
+func (asol *Asol) update(change func()) error {
+	asol.mutex.Lock()
+	before := effective(asol.requested())
+	change()
+	after := effective(asol.requested())
+	asol.mutex.Unlock()
+
+	if asol.connection() == nil {
+		return nil
+	}
+
+	for _, event := range sorted(before) {
+		if _, ok := after[event]; ok {
+			continue
+		}
+
+		message := []interface{}{wem.Unsubscribe, event}
+		err := asol.write(&message)
+
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, event := range sorted(after) {
+		if _, ok := before[event]; ok {
+			continue
+		}
+
+		message := []interface{}{wem.Subscribe, event}
+		err := asol.write(&message)
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (asol *Asol) subscribe() error {
+	asol.mutex.Lock()
+	events := sorted(effective(asol.requested()))
+	asol.mutex.Unlock()
+
+	for _, event := range events {
+		message := []interface{}{wem.Subscribe, event}
+		err := asol.write(&message)
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (asol *Asol) unsubscribe() {
+	asol.mutex.Lock()
+	events := sorted(effective(asol.requested()))
+	asol.mutex.Unlock()
+
+	for _, event := range events {
+		message := []interface{}{wem.Unsubscribe, event}
+		asol.write(&message)
+	}
+}
+
+func effective(events map[string]struct{}) map[string]struct{} {
+	if _, ok := events[wem.JsonApiEvent]; ok {
+		return map[string]struct{}{wem.JsonApiEvent: {}}
+	}
+
+	result := make(map[string]struct{}, len(events))
+
+	for event := range events {
+		result[event] = struct{}{}
+	}
+
+	return result
+}
+
+func sorted(events map[string]struct{}) []string {
+	result := make([]string, 0, len(events))
+
+	for event := range events {
+		result = append(result, event)
+	}
+
+	sort.Strings(result)
+	return result
+}
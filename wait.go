@@ -0,0 +1,108 @@
+package asol
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/braycarlson/asol/request"
+	"github.com/braycarlson/asol/wem"
+)
+
+type (
+	WaitTimeoutError struct {
+		URI string
+	}
+
+	payload struct {
+		Data json.RawMessage `json:"data"`
+	}
+)
+
+func (error *WaitTimeoutError) Error() string {
+	return fmt.Sprintf("%s did not reach the expected value in time", error.URI)
+}
+
+func (asol *Asol) WaitFor(ctx context.Context, uri string, predicate func(json.RawMessage) bool) (json.RawMessage, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	events, err := asol.StreamWith(
+		ctx,
+		uri,
+		nil,
+		wem.StreamOptions{Buffer: wem.DefaultStreamBuffer, Policy: wem.Block},
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	local := asol.client.Local()
+	get, err := local.Get(uri)
+
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := local.Request(asol.client, get.WithContext(ctx))
+
+	if err == nil && !request.IsFailure(data) && predicate(data) {
+		return data, nil
+	}
+
+	for {
+		select {
+		case message, ok := <-events:
+			if !ok {
+				if ctx.Err() == nil {
+					return nil, &NotConnectedError{}
+				}
+
+				return nil, asol.waitError(ctx, uri)
+			}
+
+			var event payload
+			err := json.Unmarshal(message.Data, &event)
+
+			if err != nil {
+				continue
+			}
+
+			if predicate(event.Data) {
+				return event.Data, nil
+			}
+		case <-ctx.Done():
+			return nil, asol.waitError(ctx, uri)
+		}
+	}
+}
+
+func (asol *Asol) waitError(ctx context.Context, uri string) error {
+	if ctx.Err() == context.DeadlineExceeded {
+		return &WaitTimeoutError{uri}
+	}
+
+	return ctx.Err()
+}
+
+func WaitFor[T any](ctx context.Context, asol *Asol, uri string, predicate func(T) bool) (T, error) {
+	var value T
+
+	data, err := asol.WaitFor(ctx, uri, func(data json.RawMessage) bool {
+		var candidate T
+
+		if json.Unmarshal(data, &candidate) != nil {
+			return false
+		}
+
+		return predicate(candidate)
+	})
+
+	if err != nil {
+		return value, err
+	}
+
+	err = json.Unmarshal(data, &value)
+	return value, err
+}
@@ -0,0 +1,111 @@
+package wem
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"sync"
+)
+
+type (
+	CallManager struct {
+		pending    map[string]chan *Response
+		identifier uint64
+		mutex      *sync.Mutex
+	}
+
+	RPCError struct {
+		CallID      string
+		URI         string
+		Description string
+		Details     json.RawMessage
+	}
+
+	CallTimeoutError struct {
+		Procedure string
+	}
+
+	CallClosedError struct {
+		Procedure string
+	}
+)
+
+func NewCallManager() *CallManager {
+	return &CallManager{
+		pending: make(map[string]chan *Response),
+		mutex:   &sync.Mutex{},
+	}
+}
+
+func (error *RPCError) Error() string {
+	if error.Description == "" {
+		return fmt.Sprintf("The call %s failed: %s", error.CallID, error.URI)
+	}
+
+	return fmt.Sprintf("The call %s failed: %s (%s)", error.CallID, error.URI, error.Description)
+}
+
+func (error *CallTimeoutError) Error() string {
+	return fmt.Sprintf("The call to %s timed out", error.Procedure)
+}
+
+func (error *CallClosedError) Error() string {
+	return fmt.Sprintf("The connection closed before %s returned", error.Procedure)
+}
+
+func (manager *CallManager) Register() (string, chan *Response) {
+	manager.mutex.Lock()
+	defer manager.mutex.Unlock()
+
+	manager.identifier++
+
+	var identifier string = strconv.FormatUint(manager.identifier, 10)
+	response := make(chan *Response, 1)
+
+	manager.pending[identifier] = response
+	return identifier, response
+}
+
+func (manager *CallManager) Forget(identifier string) {
+	manager.mutex.Lock()
+	defer manager.mutex.Unlock()
+
+	delete(manager.pending, identifier)
+}
+
+func (manager *CallManager) Resolve(response *Response) bool {
+	manager.mutex.Lock()
+	pending, ok := manager.pending[response.CallID]
+	delete(manager.pending, response.CallID)
+	manager.mutex.Unlock()
+
+	if !ok {
+		return false
+	}
+
+	pending <- response
+	return true
+}
+
+func (manager *CallManager) Close() {
+	manager.mutex.Lock()
+	defer manager.mutex.Unlock()
+
+	for identifier, pending := range manager.pending {
+		close(pending)
+		delete(manager.pending, identifier)
+	}
+}
+
+func (response *Response) Err() error {
+	if response.MessageType != CallError {
+		return nil
+	}
+
+	return &RPCError{
+		CallID:      response.CallID,
+		URI:         response.ErrorURI,
+		Description: response.Description,
+		Details:     response.Details,
+	}
+}
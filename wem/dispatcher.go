@@ -0,0 +1,154 @@
+package wem
+
+import (
+	"fmt"
+	"hash/fnv"
+	"sync"
+	"sync/atomic"
+)
+
+const (
+	Block Policy = iota
+	Drop
+)
+
+type (
+	Policy int
+
+	Dispatcher struct {
+		depth   int64
+		dropped uint64
+		manager *WebsocketEventManager
+		queues  []chan *Message
+		policy  Policy
+		closed  chan struct{}
+		stopped bool
+		once    *sync.Once
+		group   *sync.WaitGroup
+		mutex   *sync.RWMutex
+	}
+
+	QueueFullError struct {
+		URI string
+	}
+
+	DispatcherClosedError struct{}
+)
+
+func NewDispatcher(manager *WebsocketEventManager, workers int, size int, policy Policy) *Dispatcher {
+	if workers < 1 {
+		workers = 1
+	}
+
+	if size < 1 {
+		size = 1
+	}
+
+	dispatcher := &Dispatcher{
+		manager: manager,
+		queues:  make([]chan *Message, workers),
+		policy:  policy,
+		closed:  make(chan struct{}),
+		once:    &sync.Once{},
+		group:   &sync.WaitGroup{},
+		mutex:   &sync.RWMutex{},
+	}
+
+	for index := range dispatcher.queues {
+		dispatcher.queues[index] = make(chan *Message, size)
+		dispatcher.group.Add(1)
+
+		go dispatcher.work(dispatcher.queues[index])
+	}
+
+	return dispatcher
+}
+
+func (error *QueueFullError) Error() string {
+	return fmt.Sprintf("The queue for %s is full; the event was dropped", error.URI)
+}
+
+func (error *DispatcherClosedError) Error() string {
+	return "The dispatcher is closed"
+}
+
+func (dispatcher *Dispatcher) Depth() int {
+	return int(atomic.LoadInt64(&dispatcher.depth))
+}
+
+func (dispatcher *Dispatcher) Dropped() uint64 {
+	return atomic.LoadUint64(&dispatcher.dropped)
+}
+
+func (dispatcher *Dispatcher) queue(uri string) chan *Message {
+	hash := fnv.New32a()
+	hash.Write([]byte(uri))
+
+	return dispatcher.queues[hash.Sum32()%uint32(len(dispatcher.queues))]
+}
+
+func (dispatcher *Dispatcher) Dispatch(message *Message) error {
+	dispatcher.mutex.RLock()
+	defer dispatcher.mutex.RUnlock()
+
+	if dispatcher.stopped {
+		return &DispatcherClosedError{}
+	}
+
+	queue := dispatcher.queue(message.URI)
+	atomic.AddInt64(&dispatcher.depth, 1)
+
+	if dispatcher.policy == Drop {
+		select {
+		case queue <- message:
+			return nil
+		default:
+			atomic.AddInt64(&dispatcher.depth, -1)
+			atomic.AddUint64(&dispatcher.dropped, 1)
+			return &QueueFullError{message.URI}
+		}
+	}
+
+	select {
+	case queue <- message:
+		return nil
+	case <-dispatcher.closed:
+		atomic.AddInt64(&dispatcher.depth, -1)
+		return &DispatcherClosedError{}
+	}
+}
+
+func (dispatcher *Dispatcher) work(queue chan *Message) {
+	defer dispatcher.group.Done()
+
+	for message := range queue {
+		dispatcher.handle(message)
+	}
+}
+
+func (dispatcher *Dispatcher) handle(message *Message) {
+	defer atomic.AddInt64(&dispatcher.depth, -1)
+
+	err := dispatcher.manager.Match(message)
+
+	if err != nil {
+		dispatcher.manager.fail(err)
+	}
+}
+
+func (dispatcher *Dispatcher) Close() {
+	dispatcher.once.Do(func() {
+		close(dispatcher.closed)
+
+		dispatcher.mutex.Lock()
+		dispatcher.stopped = true
+
+		for _, queue := range dispatcher.queues {
+			close(queue)
+		}
+
+		dispatcher.mutex.Unlock()
+	})
+
+	dispatcher.group.Wait()
+}
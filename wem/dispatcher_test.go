@@ -0,0 +1,275 @@
+package wem
+
+import (
+	"fmt"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+)
+
+func sequence(uri string, index int) *Message {
+	return &Message{
+		URI:    uri,
+		Method: Update,
+		Data:   []byte(strconv.Itoa(index)),
+	}
+}
+
+func TestDispatcherOrdering(t *testing.T) {
+	manager := NewWebsocketEventManager()
+
+	mutex := &sync.Mutex{}
+	received := make(map[string][]int)
+
+	manager.OnRoute("/**", AnyMethod, func(data []byte, params Params) {
+		index, _ := strconv.Atoi(string(data))
+
+		mutex.Lock()
+		received[params.Get("**")] = append(received[params.Get("**")], index)
+		mutex.Unlock()
+	})
+
+	dispatcher := NewDispatcher(manager, 4, 8, Block)
+
+	const uris = 16
+	const count = 200
+
+	group := &sync.WaitGroup{}
+
+	for uri := 0; uri < uris; uri++ {
+		group.Add(1)
+
+		go func(uri string) {
+			defer group.Done()
+
+			for index := 0; index < count; index++ {
+				err := dispatcher.Dispatch(sequence("/"+uri, index))
+
+				if err != nil {
+					t.Error(err)
+				}
+			}
+		}(fmt.Sprintf("lol-lobby/v2/lobby/members/%d", uri))
+	}
+
+	group.Wait()
+	dispatcher.Close()
+
+	if len(received) != uris {
+		t.Fatalf("received events for %d uris, expected %d", len(received), uris)
+	}
+
+	for uri, indices := range received {
+		if len(indices) != count {
+			t.Errorf("%s received %d events, expected %d", uri, len(indices), count)
+		}
+
+		for position, index := range indices {
+			if position != index {
+				t.Errorf("%s received %d at position %d", uri, index, position)
+				break
+			}
+		}
+	}
+
+	if depth := dispatcher.Depth(); depth != 0 {
+		t.Errorf("Depth() = %d after Close, expected 0", depth)
+	}
+}
+
+func blocked(t *testing.T, policy Policy, size int) (*Dispatcher, chan struct{}, chan struct{}) {
+	manager := NewWebsocketEventManager()
+
+	started := make(chan struct{}, 1)
+	release := make(chan struct{})
+
+	manager.OnRoute("/lol-gameflow/v1/session", AnyMethod, func([]byte, Params) {
+		select {
+		case started <- struct{}{}:
+		default:
+		}
+
+		<-release
+	})
+
+	dispatcher := NewDispatcher(manager, 1, size, policy)
+	dispatcher.Dispatch(sequence("/lol-gameflow/v1/session", 0))
+
+	select {
+	case <-started:
+	case <-time.After(time.Second):
+		t.Fatal("the worker did not start handling the first event")
+	}
+
+	return dispatcher, started, release
+}
+
+func TestDispatcherDrop(t *testing.T) {
+	dispatcher, _, release := blocked(t, Drop, 2)
+
+	for index := 1; index <= 2; index++ {
+		err := dispatcher.Dispatch(sequence("/lol-gameflow/v1/session", index))
+
+		if err != nil {
+			t.Fatalf("Dispatch(%d) = %v", index, err)
+		}
+	}
+
+	if depth := dispatcher.Depth(); depth != 3 {
+		t.Errorf("Depth() = %d, expected 3", depth)
+	}
+
+	err := dispatcher.Dispatch(sequence("/lol-gameflow/v1/session", 3))
+
+	if _, ok := err.(*QueueFullError); !ok {
+		t.Errorf("Dispatch() = %v, expected a QueueFullError", err)
+	}
+
+	if dropped := dispatcher.Dropped(); dropped != 1 {
+		t.Errorf("Dropped() = %d, expected 1", dropped)
+	}
+
+	if depth := dispatcher.Depth(); depth != 3 {
+		t.Errorf("Depth() = %d after a drop, expected 3", depth)
+	}
+
+	close(release)
+	dispatcher.Close()
+
+	if depth := dispatcher.Depth(); depth != 0 {
+		t.Errorf("Depth() = %d after Close, expected 0", depth)
+	}
+}
+
+func TestDispatcherBlock(t *testing.T) {
+	dispatcher, _, release := blocked(t, Block, 1)
+
+	err := dispatcher.Dispatch(sequence("/lol-gameflow/v1/session", 1))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result := make(chan error, 1)
+
+	go func() {
+		result <- dispatcher.Dispatch(sequence("/lol-gameflow/v1/session", 2))
+	}()
+
+	select {
+	case err := <-result:
+		t.Fatalf("Dispatch() returned %v while the queue was full", err)
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	close(release)
+
+	select {
+	case err := <-result:
+		if err != nil {
+			t.Fatal(err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Dispatch() stayed blocked after the queue drained")
+	}
+
+	if dropped := dispatcher.Dropped(); dropped != 0 {
+		t.Errorf("Dropped() = %d, expected 0", dropped)
+	}
+
+	dispatcher.Close()
+
+	if depth := dispatcher.Depth(); depth != 0 {
+		t.Errorf("Depth() = %d after Close, expected 0", depth)
+	}
+}
+
+func TestDispatcherCloseUnblocks(t *testing.T) {
+	dispatcher, _, release := blocked(t, Block, 1)
+	dispatcher.Dispatch(sequence("/lol-gameflow/v1/session", 1))
+
+	result := make(chan error, 1)
+
+	go func() {
+		result <- dispatcher.Dispatch(sequence("/lol-gameflow/v1/session", 2))
+	}()
+
+	time.Sleep(20 * time.Millisecond)
+
+	closed := make(chan struct{})
+
+	go func() {
+		dispatcher.Close()
+		close(closed)
+	}()
+
+	select {
+	case err := <-result:
+		if _, ok := err.(*DispatcherClosedError); !ok {
+			t.Errorf("Dispatch() = %v, expected a DispatcherClosedError", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Close did not unblock a pending Dispatch")
+	}
+
+	close(release)
+	<-closed
+
+	if depth := dispatcher.Depth(); depth != 0 {
+		t.Errorf("Depth() = %d after Close, expected 0", depth)
+	}
+
+	err := dispatcher.Dispatch(sequence("/lol-gameflow/v1/session", 3))
+
+	if _, ok := err.(*DispatcherClosedError); !ok {
+		t.Errorf("Dispatch() = %v after Close, expected a DispatcherClosedError", err)
+	}
+}
+
+func TestDispatcherCloseRace(t *testing.T) {
+	for attempt := 0; attempt < 100; attempt++ {
+		manager := NewWebsocketEventManager()
+
+		var handled int64
+		mutex := &sync.Mutex{}
+
+		manager.OnRoute("/**", AnyMethod, func([]byte, Params) {
+			mutex.Lock()
+			handled++
+			mutex.Unlock()
+		})
+
+		dispatcher := NewDispatcher(manager, 2, 4, Drop)
+
+		var accepted int64
+		group := &sync.WaitGroup{}
+
+		for sender := 0; sender < 8; sender++ {
+			group.Add(1)
+
+			go func(sender int) {
+				defer group.Done()
+
+				for index := 0; index < 100; index++ {
+					if dispatcher.Dispatch(sequence(fmt.Sprintf("/lol-chat/v1/conversations/%d", sender), index)) == nil {
+						mutex.Lock()
+						accepted++
+						mutex.Unlock()
+					}
+				}
+			}(sender)
+		}
+
+		dispatcher.Close()
+		group.Wait()
+
+		if depth := dispatcher.Depth(); depth != 0 {
+			t.Fatalf("Depth() = %d after Close, expected 0", depth)
+		}
+
+		if handled != accepted {
+			t.Fatalf("handled %d events, but %d were accepted", handled, accepted)
+		}
+	}
+}
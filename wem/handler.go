@@ -0,0 +1,150 @@
+package wem
+
+import (
+	"time"
+)
+
+const (
+	Create    string = "Create"
+	Update    string = "Update"
+	Delete    string = "Delete"
+	AnyMethod string = "*"
+)
+
+type (
+	Route struct {
+		Pattern  string
+		Methods  []string
+		Once     bool
+		Timeout  time.Duration
+		Callback RouteCallback
+	}
+
+	Handler struct {
+		manager  *WebsocketEventManager
+		listener *listener
+	}
+)
+
+func (handler *Handler) Unsubscribe() {
+	handler.manager.remove(handler.listener)
+}
+
+func (handler *Handler) Pattern() string {
+	return handler.listener.pattern
+}
+
+func methods(values []string) map[string]struct{} {
+	set := make(map[string]struct{}, len(values))
+
+	for _, value := range values {
+		if value == AnyMethod || value == "" {
+			return nil
+		}
+
+		set[value] = struct{}{}
+	}
+
+	return set
+}
+
+func (wem *WebsocketEventManager) Register(route Route) (*Handler, error) {
+	var callback RouteCallback = route.Callback
+
+	return wem.register(route, func(message *Message, params Params) {
+		callback(message.Data, params)
+	})
+}
+
+func (wem *WebsocketEventManager) register(route Route, callback dispatch) (*Handler, error) {
+	wem.mutex.Lock()
+
+	wem.identifier++
+
+	listener := &listener{
+		identifier: wem.identifier,
+		pattern:    route.Pattern,
+		methods:    methods(route.Methods),
+		once:       route.Once,
+		timeout:    route.Timeout,
+		callback:   callback,
+	}
+
+	err := wem.root.insert(route.Pattern, listener)
+
+	if err != nil {
+		wem.mutex.Unlock()
+		return nil, err
+	}
+
+	wem.registered++
+	wem.mutex.Unlock()
+
+	wem.notify(registered, route.Pattern)
+
+	return &Handler{wem, listener}, nil
+}
+
+func (wem *WebsocketEventManager) remove(listener *listener) bool {
+	wem.mutex.Lock()
+	var removed bool = listener.remove()
+
+	if removed {
+		wem.registered--
+	}
+
+	wem.mutex.Unlock()
+
+	if removed {
+		wem.notify(unregistered, listener.pattern)
+	}
+
+	return removed
+}
+
+func (wem *WebsocketEventManager) OnMessage(uri string, method string, callback WebsocketCallback) (*Handler, error) {
+	return wem.Register(
+		Route{
+			Pattern: uri,
+			Methods: []string{method},
+			Callback: func(data []byte, params Params) {
+				callback(data)
+			},
+		},
+	)
+}
+
+func (wem *WebsocketEventManager) OnMethods(uri string, methods []string, callback WebsocketCallback) (*Handler, error) {
+	return wem.Register(
+		Route{
+			Pattern: uri,
+			Methods: methods,
+			Callback: func(data []byte, params Params) {
+				callback(data)
+			},
+		},
+	)
+}
+
+func (wem *WebsocketEventManager) Once(uri string, method string, callback WebsocketCallback) (*Handler, error) {
+	return wem.Register(
+		Route{
+			Pattern: uri,
+			Methods: []string{method},
+			Once:    true,
+			Callback: func(data []byte, params Params) {
+				callback(data)
+			},
+		},
+	)
+}
+
+func (wem *WebsocketEventManager) OnRoute(pattern string, method string, callback RouteCallback) (*Handler, error) {
+	return wem.Register(
+		Route{
+			Pattern:  pattern,
+			Methods:  []string{method},
+			Callback: callback,
+		},
+	)
+}
@@ -0,0 +1,95 @@
+package wem
+
+const (
+	registered   string = "register"
+	unregistered string = "unregister"
+	failed       string = "error"
+)
+
+type (
+	Hook struct {
+		manager    *WebsocketEventManager
+		kind       string
+		identifier int
+	}
+
+	observer struct {
+		identifier int
+		callback   interface{}
+	}
+)
+
+func (hook *Hook) Unsubscribe() {
+	hook.manager.mutex.Lock()
+	defer hook.manager.mutex.Unlock()
+
+	hooks := hook.manager.hooks[hook.kind]
+
+	for index, candidate := range hooks {
+		if candidate.identifier == hook.identifier {
+			updated := make([]*observer, 0, len(hooks)-1)
+			updated = append(updated, hooks[:index]...)
+			updated = append(updated, hooks[index+1:]...)
+
+			hook.manager.hooks[hook.kind] = updated
+			return
+		}
+	}
+}
+
+func (wem *WebsocketEventManager) addHook(kind string, callback interface{}) *Hook {
+	wem.mutex.Lock()
+	defer wem.mutex.Unlock()
+
+	wem.identifier++
+
+	wem.hooks[kind] = append(
+		wem.hooks[kind][:len(wem.hooks[kind]):len(wem.hooks[kind])],
+		&observer{wem.identifier, callback},
+	)
+
+	return &Hook{wem, kind, wem.identifier}
+}
+
+func (wem *WebsocketEventManager) snapshotHooks(kind string) []*observer {
+	wem.mutex.RLock()
+	defer wem.mutex.RUnlock()
+
+	return wem.hooks[kind]
+}
+
+func (wem *WebsocketEventManager) OnRegister(callback func(string)) *Hook {
+	if callback == nil {
+		return &Hook{wem, registered, 0}
+	}
+
+	return wem.addHook(registered, callback)
+}
+
+func (wem *WebsocketEventManager) OnUnregister(callback func(string)) *Hook {
+	if callback == nil {
+		return &Hook{wem, unregistered, 0}
+	}
+
+	return wem.addHook(unregistered, callback)
+}
+
+func (wem *WebsocketEventManager) OnError(callback func(error)) *Hook {
+	if callback == nil {
+		return &Hook{wem, failed, 0}
+	}
+
+	return wem.addHook(failed, callback)
+}
+
+func (wem *WebsocketEventManager) notify(kind string, pattern string) {
+	for _, observer := range wem.snapshotHooks(kind) {
+		observer.callback.(func(string))(pattern)
+	}
+}
+
+func (wem *WebsocketEventManager) fail(err error) {
+	for _, observer := range wem.snapshotHooks(failed) {
+		observer.callback.(func(error))(err)
+	}
+}
@@ -0,0 +1,84 @@
+package wem
+
+import (
+	"fmt"
+	"runtime/debug"
+	"time"
+)
+
+type (
+	HandlerPanicError struct {
+		URI     string
+		Pattern string
+		Value   interface{}
+		Stack   []byte
+	}
+
+	HandlerTimeoutError struct {
+		URI     string
+		Pattern string
+		Timeout time.Duration
+	}
+)
+
+func (error *HandlerPanicError) Error() string {
+	return fmt.Sprintf("The handler for %s panicked on %s: %v", error.Pattern, error.URI, error.Value)
+}
+
+func (error *HandlerTimeoutError) Error() string {
+	return fmt.Sprintf("The handler for %s did not return within %v on %s", error.Pattern, error.Timeout, error.URI)
+}
+
+func (wem *WebsocketEventManager) SetTimeout(timeout time.Duration) {
+	wem.mutex.Lock()
+	defer wem.mutex.Unlock()
+
+	wem.timeout = timeout
+}
+
+func (wem *WebsocketEventManager) invoke(listener *listener, message *Message, params Params) {
+	var timeout time.Duration = listener.timeout
+
+	if timeout <= 0 {
+		wem.mutex.RLock()
+		timeout = wem.timeout
+		wem.mutex.RUnlock()
+	}
+
+	if timeout <= 0 {
+		wem.call(listener, message, params)
+		return
+	}
+
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+		wem.call(listener, message, params)
+	}()
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	select {
+	case <-done:
+	case <-timer.C:
+		wem.fail(&HandlerTimeoutError{message.URI, listener.pattern, timeout})
+	}
+}
+
+func (wem *WebsocketEventManager) call(listener *listener, message *Message, params Params) {
+	defer func() {
+		recovered := recover()
+
+		if recovered == nil {
+			return
+		}
+
+		wem.fail(
+			&HandlerPanicError{message.URI, listener.pattern, recovered, debug.Stack()},
+		)
+	}()
+
+	listener.callback(message, params)
+}
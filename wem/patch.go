@@ -0,0 +1,190 @@
+package wem
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+type (
+	Operation struct {
+		Op    string          `json:"op"`
+		Path  string          `json:"path"`
+		Value json.RawMessage `json:"value,omitempty"`
+	}
+
+	Patch struct {
+		URI        string
+		EventType  string
+		Params     Params
+		Operations []Operation
+		Data       json.RawMessage
+	}
+
+	PatchCallback func(Patch)
+)
+
+func Diff(previous json.RawMessage, current json.RawMessage) ([]Operation, error) {
+	var before interface{}
+	var after interface{}
+
+	err := decode(previous, &before)
+
+	if err != nil {
+		return nil, err
+	}
+
+	err = decode(current, &after)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var operations []Operation
+	diff("", before, after, &operations)
+
+	return operations, nil
+}
+
+func diff(path string, before interface{}, after interface{}, operations *[]Operation) {
+	switch previous := before.(type) {
+	case map[string]interface{}:
+		current, ok := after.(map[string]interface{})
+
+		if !ok {
+			break
+		}
+
+		keys := make([]string, 0, len(previous)+len(current))
+
+		for key := range previous {
+			keys = append(keys, key)
+		}
+
+		for key := range current {
+			if _, ok := previous[key]; !ok {
+				keys = append(keys, key)
+			}
+		}
+
+		sort.Strings(keys)
+
+		for _, key := range keys {
+			var child string = path + "/" + escape(key)
+			value, existed := previous[key]
+			updated, exists := current[key]
+
+			switch {
+			case existed && !exists:
+				*operations = append(*operations, Operation{Op: "remove", Path: child})
+			case !existed && exists:
+				*operations = append(*operations, Operation{Op: "add", Path: child, Value: encode(updated)})
+			default:
+				diff(child, value, updated, operations)
+			}
+		}
+
+		return
+	case []interface{}:
+		current, ok := after.([]interface{})
+
+		if !ok || len(current) != len(previous) {
+			break
+		}
+
+		for index := range previous {
+			diff(path+"/"+strconv.Itoa(index), previous[index], current[index], operations)
+		}
+
+		return
+	}
+
+	if reflect.DeepEqual(before, after) {
+		return
+	}
+
+	*operations = append(*operations, Operation{Op: "replace", Path: path, Value: encode(after)})
+}
+
+func decode(data json.RawMessage, value *interface{}) error {
+	if len(data) == 0 {
+		return nil
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	return decoder.Decode(value)
+}
+
+func escape(key string) string {
+	key = strings.ReplaceAll(key, "~", "~0")
+	return strings.ReplaceAll(key, "/", "~1")
+}
+
+func encode(value interface{}) json.RawMessage {
+	data, _ := json.Marshal(value)
+	return data
+}
+
+func (wem *WebsocketEventManager) OnPatch(pattern string, method string, callback PatchCallback) (*Handler, error) {
+	return wem.RegisterPatch(Route{Pattern: pattern, Methods: []string{method}}, callback)
+}
+
+func (wem *WebsocketEventManager) RegisterPatch(route Route, callback PatchCallback) (*Handler, error) {
+	previous := make(map[string]json.RawMessage)
+	mutex := &sync.Mutex{}
+
+	return wem.register(route, func(message *Message, params Params) {
+		var payload envelope[json.RawMessage]
+		err := json.Unmarshal(message.Data, &payload)
+
+		if err != nil {
+			wem.fail(&DecodeError{message.URI, err})
+			return
+		}
+
+		var operations []Operation
+
+		mutex.Lock()
+		last, ok := previous[message.URI]
+
+		switch {
+		case message.Method == Delete && !ok:
+			operations = []Operation{}
+		case message.Method == Delete:
+			// A remove at the root path discards the whole document.
+			delete(previous, message.URI)
+			operations = []Operation{{Op: "remove", Path: ""}}
+		case !ok:
+			previous[message.URI] = payload.Data
+			operations = []Operation{{Op: "add", Path: "", Value: payload.Data}}
+		case bytes.Equal(last, payload.Data):
+			operations = []Operation{}
+		default:
+			previous[message.URI] = payload.Data
+			operations, err = Diff(last, payload.Data)
+		}
+
+		mutex.Unlock()
+
+		if err != nil {
+			wem.fail(&DecodeError{message.URI, err})
+			return
+		}
+
+		callback(
+			Patch{
+				URI:        message.URI,
+				EventType:  message.Method,
+				Params:     params,
+				Operations: operations,
+				Data:       payload.Data,
+			},
+		)
+	})
+}
@@ -0,0 +1,210 @@
+package wem
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestDiff(t *testing.T) {
+	tests := []struct {
+		name     string
+		previous string
+		current  string
+		expected []Operation
+	}{
+		{
+			name:     "equal",
+			previous: `{"phase":"Lobby","counter":1}`,
+			current:  `{"counter":1,"phase":"Lobby"}`,
+		},
+		{
+			name:     "replace a value",
+			previous: `{"phase":"Lobby"}`,
+			current:  `{"phase":"Matchmaking"}`,
+			expected: []Operation{{Op: "replace", Path: "/phase", Value: json.RawMessage(`"Matchmaking"`)}},
+		},
+		{
+			name:     "add and remove keys in sorted order",
+			previous: `{"b":1,"c":2}`,
+			current:  `{"a":0,"c":2}`,
+			expected: []Operation{
+				{Op: "add", Path: "/a", Value: json.RawMessage(`0`)},
+				{Op: "remove", Path: "/b"},
+			},
+		},
+		{
+			name:     "nested objects",
+			previous: `{"timer":{"phase":"BAN_PICK","adjustedTimeLeftInPhase":26874}}`,
+			current:  `{"timer":{"phase":"BAN_PICK","adjustedTimeLeftInPhase":25874}}`,
+			expected: []Operation{{Op: "replace", Path: "/timer/adjustedTimeLeftInPhase", Value: json.RawMessage(`25874`)}},
+		},
+		{
+			name:     "escape tilde and slash",
+			previous: `{"a~b":1,"c/d":1,"~1":1}`,
+			current:  `{"a~b":2,"c/d":2,"~1":2}`,
+			expected: []Operation{
+				{Op: "replace", Path: "/a~0b", Value: json.RawMessage(`2`)},
+				{Op: "replace", Path: "/c~1d", Value: json.RawMessage(`2`)},
+				{Op: "replace", Path: "/~01", Value: json.RawMessage(`2`)},
+			},
+		},
+		{
+			name:     "array elements of the same length",
+			previous: `{"myTeam":[{"championId":0},{"championId":64}]}`,
+			current:  `{"myTeam":[{"championId":86},{"championId":64}]}`,
+			expected: []Operation{{Op: "replace", Path: "/myTeam/0/championId", Value: json.RawMessage(`86`)}},
+		},
+		{
+			name:     "array length changes",
+			previous: `{"bans":[157]}`,
+			current:  `{"bans":[157,238]}`,
+			expected: []Operation{{Op: "replace", Path: "/bans", Value: json.RawMessage(`[157,238]`)}},
+		},
+		{
+			name:     "type changes",
+			previous: `{"data":{"a":1}}`,
+			current:  `{"data":[1]}`,
+			expected: []Operation{{Op: "replace", Path: "/data", Value: json.RawMessage(`[1]`)}},
+		},
+		{
+			name:     "null to value",
+			previous: `{"data":null}`,
+			current:  `{"data":"x"}`,
+			expected: []Operation{{Op: "replace", Path: "/data", Value: json.RawMessage(`"x"`)}},
+		},
+		{
+			name:     "root scalar",
+			previous: `"Lobby"`,
+			current:  `"None"`,
+			expected: []Operation{{Op: "replace", Path: "", Value: json.RawMessage(`"None"`)}},
+		},
+		{
+			name:     "large numbers keep their precision",
+			previous: `{"gameId":4822190311000000001}`,
+			current:  `{"gameId":4822190311000000002}`,
+			expected: []Operation{{Op: "replace", Path: "/gameId", Value: json.RawMessage(`4822190311000000002`)}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			operations, err := Diff(json.RawMessage(test.previous), json.RawMessage(test.current))
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !reflect.DeepEqual(operations, test.expected) {
+				t.Errorf("Diff() = %s, expected %s", encode(operations), encode(test.expected))
+			}
+		})
+	}
+}
+
+func TestDiffInvalid(t *testing.T) {
+	_, err := Diff(json.RawMessage(`{"a":`), json.RawMessage(`{}`))
+
+	if err == nil {
+		t.Error("Diff() accepted invalid JSON")
+	}
+}
+
+func TestRegisterPatch(t *testing.T) {
+	manager := NewWebsocketEventManager()
+
+	var patches []Patch
+
+	_, err := manager.RegisterPatch(
+		Route{Pattern: "/lol-lobby/v2/lobby/members/{id}"},
+		func(patch Patch) {
+			patches = append(patches, patch)
+		},
+	)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	events := []struct {
+		method string
+		data   string
+	}{
+		{Create, `{"ready":false}`},
+		{Update, `{"ready":true}`},
+		{Update, `{"ready":true}`},
+		{Delete, `null`},
+		{Delete, `null`},
+	}
+
+	for _, event := range events {
+		payload, _ := json.Marshal(
+			map[string]interface{}{
+				"uri":       "/lol-lobby/v2/lobby/members/7",
+				"eventType": event.method,
+				"data":      json.RawMessage(event.data),
+			},
+		)
+
+		manager.Match(
+			&Message{
+				URI:    "/lol-lobby/v2/lobby/members/7",
+				Method: event.method,
+				Data:   payload,
+			},
+		)
+	}
+
+	expected := [][]Operation{
+		{{Op: "add", Path: "", Value: json.RawMessage(`{"ready":false}`)}},
+		{{Op: "replace", Path: "/ready", Value: json.RawMessage(`true`)}},
+		{},
+		{{Op: "remove", Path: ""}},
+		{},
+	}
+
+	if len(patches) != len(expected) {
+		t.Fatalf("received %d patches, expected %d", len(patches), len(expected))
+	}
+
+	for index, patch := range patches {
+		if !reflect.DeepEqual(patch.Operations, expected[index]) {
+			t.Errorf("patch %d = %s, expected %s", index, encode(patch.Operations), encode(expected[index]))
+		}
+
+		if patch.EventType != events[index].method || patch.Params.Get("id") != "7" {
+			t.Errorf("patch %d = %+v", index, patch)
+		}
+	}
+}
+
+func TestRegisterPatchRoute(t *testing.T) {
+	manager := NewWebsocketEventManager()
+
+	var patches int
+
+	manager.RegisterPatch(
+		Route{
+			Pattern: "/lol-gameflow/v1/session",
+			Methods: []string{Update},
+			Once:    true,
+		},
+		func(patch Patch) {
+			patches++
+		},
+	)
+
+	for _, method := range []string{Create, Update, Update} {
+		manager.Match(
+			&Message{
+				URI:    "/lol-gameflow/v1/session",
+				Method: method,
+				Data:   json.RawMessage(`{"data":{}}`),
+			},
+		)
+	}
+
+	if patches != 1 {
+		t.Errorf("received %d patches, expected 1", patches)
+	}
+}
@@ -0,0 +1,225 @@
+package wem
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"sync"
+	"time"
+)
+
+type (
+	Record struct {
+		Time  time.Time       `json:"time"`
+		Frame json.RawMessage `json:"frame"`
+	}
+
+	Recorder struct {
+		encoder *json.Encoder
+		closer  io.Closer
+		mutex   *sync.Mutex
+	}
+
+	Replayer struct {
+		manager  *WebsocketEventManager
+		reader   *bufio.Reader
+		closer   io.Closer
+		speed    float64
+		previous time.Time
+	}
+)
+
+func NewRecorder(writer io.Writer) *Recorder {
+	recorder := &Recorder{
+		encoder: json.NewEncoder(writer),
+		mutex:   &sync.Mutex{},
+	}
+
+	if closer, ok := writer.(io.Closer); ok {
+		recorder.closer = closer
+	}
+
+	return recorder
+}
+
+func CreateRecorder(path string) (*Recorder, error) {
+	file, err := os.Create(path)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return NewRecorder(file), nil
+}
+
+func (recorder *Recorder) Record(frame []byte) error {
+	var data json.RawMessage = frame
+
+	if !json.Valid(frame) {
+		data, _ = json.Marshal(string(frame))
+	}
+
+	recorder.mutex.Lock()
+	defer recorder.mutex.Unlock()
+
+	return recorder.encoder.Encode(
+		&Record{
+			Time:  time.Now(),
+			Frame: data,
+		},
+	)
+}
+
+func (recorder *Recorder) Close() error {
+	if recorder.closer == nil {
+		return nil
+	}
+
+	return recorder.closer.Close()
+}
+
+func NewReplayer(manager *WebsocketEventManager, reader io.Reader) *Replayer {
+	replayer := &Replayer{
+		manager: manager,
+		reader:  bufio.NewReader(reader),
+		speed:   1,
+	}
+
+	if closer, ok := reader.(io.Closer); ok {
+		replayer.closer = closer
+	}
+
+	return replayer
+}
+
+func OpenReplayer(manager *WebsocketEventManager, path string) (*Replayer, error) {
+	file, err := os.Open(path)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return NewReplayer(manager, file), nil
+}
+
+func (replayer *Replayer) SetSpeed(speed float64) {
+	replayer.speed = speed
+}
+
+func (replayer *Replayer) Close() error {
+	if replayer.closer == nil {
+		return nil
+	}
+
+	return replayer.closer.Close()
+}
+
+func (replayer *Replayer) next() (*Record, error) {
+	for {
+		line, err := replayer.reader.ReadBytes('\n')
+
+		if len(line) == 0 && err != nil {
+			return nil, err
+		}
+
+		if len(line) == 0 || (len(line) == 1 && line[0] == '\n') {
+			continue
+		}
+
+		var record Record
+		err = json.Unmarshal(line, &record)
+
+		if err != nil {
+			return nil, &MalformedFrameError{string(line), err.Error()}
+		}
+
+		return &record, nil
+	}
+}
+
+func (replayer *Replayer) Step() (bool, error) {
+	record, err := replayer.next()
+
+	if err == io.EOF {
+		return false, nil
+	}
+
+	if err != nil {
+		return false, err
+	}
+
+	replayer.previous = record.Time
+	replayer.play(record)
+
+	return true, nil
+}
+
+func (replayer *Replayer) Replay(ctx context.Context) error {
+	for {
+		record, err := replayer.next()
+
+		if err == io.EOF {
+			return nil
+		}
+
+		if err != nil {
+			return err
+		}
+
+		err = replayer.wait(ctx, record.Time)
+
+		if err != nil {
+			return err
+		}
+
+		replayer.previous = record.Time
+		replayer.play(record)
+	}
+}
+
+func (replayer *Replayer) wait(ctx context.Context, next time.Time) error {
+	if replayer.speed <= 0 || replayer.previous.IsZero() || !next.After(replayer.previous) {
+		return ctx.Err()
+	}
+
+	var delay time.Duration = time.Duration(float64(next.Sub(replayer.previous)) / replayer.speed)
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (replayer *Replayer) play(record *Record) {
+	var response Response
+	err := json.Unmarshal(record.Frame, &response)
+
+	if err != nil {
+		replayer.manager.fail(err)
+		return
+	}
+
+	if response.MessageType != Event {
+		return
+	}
+
+	message, err := response.Message()
+
+	if err != nil {
+		replayer.manager.fail(err)
+		return
+	}
+
+	err = replayer.manager.Match(message)
+
+	if err != nil {
+		replayer.manager.fail(err)
+	}
+}
@@ -0,0 +1,275 @@
+package wem
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+type (
+	Params map[string]string
+
+	RouteCallback func([]byte, Params)
+
+	dispatch func(*Message, Params)
+
+	InvalidPatternError struct {
+		Pattern string
+		Reason  string
+	}
+
+	listener struct {
+		identifier int
+		pattern    string
+		methods    map[string]struct{}
+		once       bool
+		timeout    time.Duration
+		removed    int32
+		callback   dispatch
+		node       *node
+	}
+
+	parameter struct {
+		name       string
+		expression *regexp.Regexp
+		node       *node
+	}
+
+	node struct {
+		static     map[string]*node
+		parameters []*parameter
+		wildcard   *node
+		catchall   *node
+		listeners  []*listener
+	}
+
+	match struct {
+		listener *listener
+		params   Params
+	}
+)
+
+func newNode() *node {
+	return &node{
+		static: make(map[string]*node),
+	}
+}
+
+func (error *InvalidPatternError) Error() string {
+	return fmt.Sprintf("The pattern %q is invalid: %s", error.Pattern, error.Reason)
+}
+
+func (params Params) Get(name string) string {
+	return params[name]
+}
+
+func IsPattern(uri string) bool {
+	return strings.ContainsAny(uri, "{*")
+}
+
+func split(uri string) []string {
+	return strings.Split(strings.Trim(uri, "/"), "/")
+}
+
+func (root *node) insert(pattern string, listener *listener) error {
+	var current *node = root
+	segments := split(pattern)
+	names := make(map[string]struct{})
+
+	for index, segment := range segments {
+		switch {
+		case segment == "**":
+			if index != len(segments)-1 {
+				return &InvalidPatternError{pattern, "** must be the last segment"}
+			}
+
+			if current.catchall == nil {
+				current.catchall = newNode()
+			}
+
+			current = current.catchall
+		case segment == "*":
+			if current.wildcard == nil {
+				current.wildcard = newNode()
+			}
+
+			current = current.wildcard
+		case strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}"):
+			name, source := definition(segment[1 : len(segment)-1])
+
+			if _, ok := names[name]; ok {
+				return &InvalidPatternError{pattern, fmt.Sprintf("the parameter %q is repeated", name)}
+			}
+
+			names[name] = struct{}{}
+			child, err := current.parameter(pattern, name, source)
+
+			if err != nil {
+				return err
+			}
+
+			current = child
+		default:
+			if strings.ContainsAny(segment, "{}*") {
+				return &InvalidPatternError{pattern, fmt.Sprintf("%q is not a valid segment", segment)}
+			}
+
+			child, ok := current.static[segment]
+
+			if !ok {
+				child = newNode()
+				current.static[segment] = child
+			}
+
+			current = child
+		}
+	}
+
+	listener.node = current
+	current.listeners = append(current.listeners, listener)
+
+	return nil
+}
+
+func (listener *listener) remove() bool {
+	if !atomic.CompareAndSwapInt32(&listener.removed, 0, 1) {
+		return false
+	}
+
+	var current *node = listener.node
+
+	for index, candidate := range current.listeners {
+		if candidate == listener {
+			current.listeners = append(
+				current.listeners[:index:index],
+				current.listeners[index+1:]...,
+			)
+
+			break
+		}
+	}
+
+	return true
+}
+
+func (listener *listener) accepts(method string) bool {
+	if atomic.LoadInt32(&listener.removed) == 1 {
+		return false
+	}
+
+	if len(listener.methods) == 0 {
+		return true
+	}
+
+	_, ok := listener.methods[method]
+	return ok
+}
+
+func definition(segment string) (string, string) {
+	if index := strings.Index(segment, ":"); index != -1 {
+		return segment[:index], segment[index+1:]
+	}
+
+	return segment, ""
+}
+
+func (current *node) parameter(pattern string, name string, source string) (*node, error) {
+	if name == "" {
+		return nil, &InvalidPatternError{pattern, "a parameter is missing a name"}
+	}
+
+	for _, candidate := range current.parameters {
+		if candidate.name != name {
+			continue
+		}
+
+		if candidate.expression == nil && source == "" {
+			return candidate.node, nil
+		}
+
+		if candidate.expression != nil && candidate.expression.String() == "^(?:"+source+")$" {
+			return candidate.node, nil
+		}
+	}
+
+	var expression *regexp.Regexp
+
+	if source != "" {
+		compiled, err := regexp.Compile("^(?:" + source + ")$")
+
+		if err != nil {
+			return nil, &InvalidPatternError{pattern, err.Error()}
+		}
+
+		expression = compiled
+	}
+
+	child := &parameter{name, expression, newNode()}
+	current.parameters = append(current.parameters, child)
+
+	return child.node, nil
+}
+
+func (root *node) lookup(uri string) []*match {
+	var matches []*match
+	root.search(split(uri), Params{}, &matches)
+
+	sort.Slice(matches, func(i int, j int) bool {
+		return matches[i].listener.identifier < matches[j].listener.identifier
+	})
+
+	return matches
+}
+
+func (current *node) search(segments []string, params Params, matches *[]*match) {
+	if current.catchall != nil {
+		captured := copyParams(params)
+		captured["**"] = strings.Join(segments, "/")
+		current.catchall.collect(captured, matches)
+	}
+
+	if len(segments) == 0 {
+		current.collect(params, matches)
+		return
+	}
+
+	var segment string = segments[0]
+	var remaining []string = segments[1:]
+
+	if child, ok := current.static[segment]; ok {
+		child.search(remaining, params, matches)
+	}
+
+	for _, child := range current.parameters {
+		if child.expression != nil && !child.expression.MatchString(segment) {
+			continue
+		}
+
+		captured := copyParams(params)
+		captured[child.name] = segment
+		child.node.search(remaining, captured, matches)
+	}
+
+	if current.wildcard != nil {
+		current.wildcard.search(remaining, params, matches)
+	}
+}
+
+func (current *node) collect(params Params, matches *[]*match) {
+	for _, listener := range current.listeners {
+		*matches = append(*matches, &match{listener, params})
+	}
+}
+
+func copyParams(params Params) Params {
+	captured := make(Params, len(params)+1)
+
+	for key, value := range params {
+		captured[key] = value
+	}
+
+	return captured
+}
@@ -0,0 +1,270 @@
+package wem
+
+import (
+	"reflect"
+	"testing"
+)
+
+type call struct {
+	pattern string
+	params  Params
+}
+
+func TestRoute(t *testing.T) {
+	tests := []struct {
+		name     string
+		patterns []string
+		uri      string
+		expected []call
+	}{
+		{
+			name:     "static",
+			patterns: []string{"/lol-gameflow/v1/session"},
+			uri:      "/lol-gameflow/v1/session",
+			expected: []call{{"/lol-gameflow/v1/session", Params{}}},
+		},
+		{
+			name:     "static does not match a longer uri",
+			patterns: []string{"/lol-gameflow/v1/session"},
+			uri:      "/lol-gameflow/v1/session/extra",
+		},
+		{
+			name:     "parameter",
+			patterns: []string{"/lol-lobby/v2/lobby/members/{id}"},
+			uri:      "/lol-lobby/v2/lobby/members/2881938423",
+			expected: []call{{"/lol-lobby/v2/lobby/members/{id}", Params{"id": "2881938423"}}},
+		},
+		{
+			name:     "several parameters",
+			patterns: []string{"/lol-chat/v1/conversations/{conversation}/messages/{message}"},
+			uri:      "/lol-chat/v1/conversations/c1~abc/messages/42",
+			expected: []call{{"/lol-chat/v1/conversations/{conversation}/messages/{message}", Params{"conversation": "c1~abc", "message": "42"}}},
+		},
+		{
+			name:     "regex parameter",
+			patterns: []string{"/lol-champ-select/v1/summoners/{cell:[0-9]+}"},
+			uri:      "/lol-champ-select/v1/summoners/3",
+			expected: []call{{"/lol-champ-select/v1/summoners/{cell:[0-9]+}", Params{"cell": "3"}}},
+		},
+		{
+			name:     "regex parameter rejects a segment",
+			patterns: []string{"/lol-champ-select/v1/summoners/{cell:[0-9]+}"},
+			uri:      "/lol-champ-select/v1/summoners/abc",
+		},
+		{
+			name:     "regex parameter is anchored",
+			patterns: []string{"/lol-champ-select/v1/summoners/{cell:[0-9]}"},
+			uri:      "/lol-champ-select/v1/summoners/12",
+		},
+		{
+			name:     "wildcard",
+			patterns: []string{"/lol-chat/v1/conversations/*/messages"},
+			uri:      "/lol-chat/v1/conversations/c1~abc/messages",
+			expected: []call{{"/lol-chat/v1/conversations/*/messages", Params{}}},
+		},
+		{
+			name:     "wildcard matches exactly one segment",
+			patterns: []string{"/lol-chat/v1/*"},
+			uri:      "/lol-chat/v1/conversations/c1~abc",
+		},
+		{
+			name:     "trailing catchall",
+			patterns: []string{"/lol-champ-select/**"},
+			uri:      "/lol-champ-select/v1/session/timer",
+			expected: []call{{"/lol-champ-select/**", Params{"**": "v1/session/timer"}}},
+		},
+		{
+			name:     "trailing catchall matches its parent",
+			patterns: []string{"/lol-champ-select/**"},
+			uri:      "/lol-champ-select",
+			expected: []call{{"/lol-champ-select/**", Params{"**": ""}}},
+		},
+		{
+			name:     "trailing catchall keeps parameters",
+			patterns: []string{"/lol-lobby/v2/lobby/members/{id}/**"},
+			uri:      "/lol-lobby/v2/lobby/members/7/ready",
+			expected: []call{{"/lol-lobby/v2/lobby/members/{id}/**", Params{"id": "7", "**": "ready"}}},
+		},
+		{
+			name: "every matching route runs in registration order",
+			patterns: []string{
+				"/lol-lobby/**",
+				"/lol-lobby/v2/lobby/members/*",
+				"/lol-lobby/v2/lobby/members/{id}",
+				"/lol-lobby/v2/lobby/members/7",
+				"/lol-lobby/v2/lobby/members/{id:[a-z]+}",
+				"/lol-lobby/v2/lobby/**",
+			},
+			uri: "/lol-lobby/v2/lobby/members/7",
+			expected: []call{
+				{"/lol-lobby/**", Params{"**": "v2/lobby/members/7"}},
+				{"/lol-lobby/v2/lobby/members/*", Params{}},
+				{"/lol-lobby/v2/lobby/members/{id}", Params{"id": "7"}},
+				{"/lol-lobby/v2/lobby/members/7", Params{}},
+				{"/lol-lobby/v2/lobby/**", Params{"**": "members/7"}},
+			},
+		},
+		{
+			name: "parameters with the same name and different expressions",
+			patterns: []string{
+				"/lol-summoner/v1/summoners/{id:[0-9]+}",
+				"/lol-summoner/v1/summoners/{id:[a-z]+}",
+			},
+			uri:      "/lol-summoner/v1/summoners/riot",
+			expected: []call{{"/lol-summoner/v1/summoners/{id:[a-z]+}", Params{"id": "riot"}}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			manager := NewWebsocketEventManager()
+
+			var calls []call
+
+			for _, pattern := range test.patterns {
+				var pattern string = pattern
+
+				_, err := manager.OnRoute(pattern, AnyMethod, func(data []byte, params Params) {
+					calls = append(calls, call{pattern, params})
+				})
+
+				if err != nil {
+					t.Fatalf("OnRoute(%q) returned an error: %v", pattern, err)
+				}
+			}
+
+			err := manager.Match(&Message{URI: test.uri, Method: Update})
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !reflect.DeepEqual(calls, test.expected) {
+				t.Errorf("Match(%q) = %v, expected %v", test.uri, calls, test.expected)
+			}
+		})
+	}
+}
+
+func TestInvalidPattern(t *testing.T) {
+	patterns := []string{
+		"/lol-lobby/**/members",
+		"/lol-lobby/v2/lobby/members/{}",
+		"/lol-lobby/v2/lobby/members/{:[0-9]+}",
+		"/lol-lobby/v2/lobby/members/{id:[0-9}",
+		"/lol-lobby/v2/lobby/members/{id}/{id}",
+		"/lol-lobby/v2/{id}/members/{id:[0-9]+}",
+		"/lol-lobby/v2/lobby/members/{id",
+		"/lol-lobby/v2/lobby/members/id*",
+	}
+
+	for _, pattern := range patterns {
+		manager := NewWebsocketEventManager()
+		handler, err := manager.OnRoute(pattern, AnyMethod, func([]byte, Params) {})
+
+		if _, ok := err.(*InvalidPatternError); !ok {
+			t.Errorf("OnRoute(%q) = %v, %v, expected an InvalidPatternError", pattern, handler, err)
+		}
+
+		if manager.Registered() == nil {
+			t.Errorf("OnRoute(%q) registered a handler", pattern)
+		}
+	}
+}
+
+func TestMethods(t *testing.T) {
+	manager := NewWebsocketEventManager()
+
+	var methods []string
+
+	manager.OnMethods("/lol-gameflow/v1/session", []string{Create, Delete}, func([]byte) {
+		methods = append(methods, "filtered")
+	})
+
+	manager.OnMessage("/lol-gameflow/v1/session", AnyMethod, func([]byte) {
+		methods = append(methods, "any")
+	})
+
+	for _, method := range []string{Create, Update, Delete} {
+		manager.Match(&Message{URI: "/lol-gameflow/v1/session", Method: method})
+	}
+
+	expected := []string{"filtered", "any", "any", "filtered", "any"}
+
+	if !reflect.DeepEqual(methods, expected) {
+		t.Errorf("calls = %v, expected %v", methods, expected)
+	}
+}
+
+func TestOnceRemovedDuringMatch(t *testing.T) {
+	manager := NewWebsocketEventManager()
+
+	var calls []string
+	var unregistered []string
+
+	manager.OnUnregister(func(pattern string) {
+		unregistered = append(unregistered, pattern)
+	})
+
+	manager.Once("/lol-matchmaking/v1/ready-check", Update, func([]byte) {
+		calls = append(calls, "once")
+	})
+
+	manager.Register(
+		Route{
+			Pattern: "/lol-matchmaking/v1/*",
+			Methods: []string{Update},
+			Once:    true,
+			Callback: func([]byte, Params) {
+				calls = append(calls, "once pattern")
+			},
+		},
+	)
+
+	manager.OnMessage("/lol-matchmaking/v1/ready-check", Update, func([]byte) {
+		calls = append(calls, "always")
+	})
+
+	manager.Match(&Message{URI: "/lol-matchmaking/v1/ready-check", Method: Create})
+	manager.Match(&Message{URI: "/lol-matchmaking/v1/ready-check", Method: Update})
+	manager.Match(&Message{URI: "/lol-matchmaking/v1/ready-check", Method: Update})
+
+	expected := []string{"once", "once pattern", "always", "always"}
+
+	if !reflect.DeepEqual(calls, expected) {
+		t.Errorf("calls = %v, expected %v", calls, expected)
+	}
+
+	expected = []string{"/lol-matchmaking/v1/ready-check", "/lol-matchmaking/v1/*"}
+
+	if !reflect.DeepEqual(unregistered, expected) {
+		t.Errorf("unregistered = %v, expected %v", unregistered, expected)
+	}
+
+	if manager.registered != 1 {
+		t.Errorf("registered = %d, expected 1", manager.registered)
+	}
+}
+
+func TestUnsubscribe(t *testing.T) {
+	manager := NewWebsocketEventManager()
+
+	var calls int
+
+	handler, _ := manager.OnRoute("/lol-lobby/v2/lobby/members/{id}", AnyMethod, func([]byte, Params) {
+		calls++
+	})
+
+	manager.Match(&Message{URI: "/lol-lobby/v2/lobby/members/1", Method: Update})
+	handler.Unsubscribe()
+	handler.Unsubscribe()
+	manager.Match(&Message{URI: "/lol-lobby/v2/lobby/members/1", Method: Update})
+
+	if calls != 1 {
+		t.Errorf("calls = %d, expected 1", calls)
+	}
+
+	if _, ok := manager.Registered().(*NoRegisteredEventError); !ok {
+		t.Errorf("Registered() = %v, expected no handlers", manager.Registered())
+	}
+}
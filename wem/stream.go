@@ -0,0 +1,112 @@
+package wem
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+const DefaultStreamBuffer int = 64
+
+type (
+	StreamOptions struct {
+		Buffer int
+		Policy Policy
+	}
+
+	StreamOverflowError struct {
+		Pattern string
+		URI     string
+	}
+
+	stream struct {
+		ctx     context.Context
+		channel chan Message
+		policy  Policy
+		pattern string
+		closed  bool
+		mutex   *sync.Mutex
+	}
+)
+
+func (error *StreamOverflowError) Error() string {
+	return fmt.Sprintf("The stream for %s is full; the event for %s was dropped", error.Pattern, error.URI)
+}
+
+func (wem *WebsocketEventManager) Stream(ctx context.Context, pattern string, methods ...string) (<-chan Message, error) {
+	return wem.StreamWith(
+		ctx,
+		pattern,
+		methods,
+		StreamOptions{DefaultStreamBuffer, Drop},
+	)
+}
+
+func (wem *WebsocketEventManager) StreamWith(ctx context.Context, pattern string, methods []string, options StreamOptions) (<-chan Message, error) {
+	if options.Buffer < 0 {
+		options.Buffer = 0
+	}
+
+	stream := &stream{
+		ctx:     ctx,
+		channel: make(chan Message, options.Buffer),
+		policy:  options.Policy,
+		pattern: pattern,
+		mutex:   &sync.Mutex{},
+	}
+
+	handler, err := wem.register(
+		Route{Pattern: pattern, Methods: methods},
+		func(message *Message, params Params) {
+			if !stream.send(message) {
+				wem.fail(&StreamOverflowError{pattern, message.URI})
+			}
+		},
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	go func() {
+		<-ctx.Done()
+
+		handler.Unsubscribe()
+		stream.close()
+	}()
+
+	return stream.channel, nil
+}
+
+func (stream *stream) send(message *Message) bool {
+	stream.mutex.Lock()
+	defer stream.mutex.Unlock()
+
+	if stream.closed {
+		return true
+	}
+
+	if stream.policy == Drop {
+		select {
+		case stream.channel <- *message:
+			return true
+		default:
+			return false
+		}
+	}
+
+	select {
+	case stream.channel <- *message:
+	case <-stream.ctx.Done():
+	}
+
+	return true
+}
+
+func (stream *stream) close() {
+	stream.mutex.Lock()
+	defer stream.mutex.Unlock()
+
+	stream.closed = true
+	close(stream.channel)
+}
@@ -0,0 +1,57 @@
+package wem
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+type (
+	TypedEvent[T any] struct {
+		URI       string
+		EventType string
+		Params    Params
+		Data      T
+	}
+
+	DecodeError struct {
+		URI   string
+		error error
+	}
+
+	envelope[T any] struct {
+		Data T `json:"data"`
+	}
+)
+
+func (error *DecodeError) Error() string {
+	return fmt.Sprintf("The event for %s could not be decoded: %v", error.URI, error.error)
+}
+
+func (error *DecodeError) Unwrap() error {
+	return error.error
+}
+
+func On[T any](wem *WebsocketEventManager, pattern string, method string, callback func(TypedEvent[T])) (*Handler, error) {
+	return Register(wem, Route{Pattern: pattern, Methods: []string{method}}, callback)
+}
+
+func Register[T any](wem *WebsocketEventManager, route Route, callback func(TypedEvent[T])) (*Handler, error) {
+	return wem.register(route, func(message *Message, params Params) {
+		var decoded envelope[T]
+		err := json.Unmarshal(message.Data, &decoded)
+
+		if err != nil {
+			wem.fail(&DecodeError{message.URI, err})
+			return
+		}
+
+		callback(
+			TypedEvent[T]{
+				URI:       message.URI,
+				EventType: message.Method,
+				Params:    params,
+				Data:      decoded.Data,
+			},
+		)
+	})
+}
@@ -3,8 +3,13 @@ package wem
 import (
 	"encoding/json"
 	"fmt"
+	"strings"
+	"sync"
+	"time"
 )
 
+const JsonApiEvent string = "OnJsonApiEvent"
+
 const (
 	Welcome     float64 = 0
 	Prefix      float64 = 1
@@ -23,72 +28,213 @@ type (
 	Message struct {
 		URI    string
 		Method string
-		Data   map[string]interface{}
+		Data   json.RawMessage
 	}
 
 	Response struct {
 		MessageType float64
+		SessionID   string
+		Version     float64
+		Server      string
 		Event       string
-		Data        map[string]interface{}
+		Data        json.RawMessage
+		CallID      string
+		Result      json.RawMessage
+		ErrorURI    string
+		Description string
+		Details     json.RawMessage
 	}
 
 	WebsocketEventManager struct {
-		registered []map[string]interface{}
+		root       *node
+		identifier int
+		registered int
+		hooks      map[string][]*observer
+		timeout    time.Duration
+		mutex      *sync.RWMutex
 	}
 
 	NoRegisteredEventError struct{}
+
+	header struct {
+		URI       *string `json:"uri"`
+		EventType *string `json:"eventType"`
+	}
+
+	MalformedFrameError struct {
+		Frame  string
+		Reason string
+	}
+
+	UnexpectedFrameError struct {
+		MessageType float64
+	}
 )
 
+func (error *UnexpectedFrameError) Error() string {
+	return fmt.Sprintf("A frame of type %v was not expected", error.MessageType)
+}
+
+func (error *MalformedFrameError) Error() string {
+	return fmt.Sprintf("The frame %q is malformed: %s", error.Frame, error.Reason)
+}
+
 func (error *NoRegisteredEventError) Error() string {
 	return fmt.Sprintf("No event(s) registered.")
 }
 
+func NewWebsocketEventManager() *WebsocketEventManager {
+	return &WebsocketEventManager{
+		root:  newNode(),
+		hooks: make(map[string][]*observer),
+		mutex: &sync.RWMutex{},
+	}
+}
+
+func EventName(uri string) string {
+	if IsPattern(uri) {
+		return JsonApiEvent
+	}
+
+	return JsonApiEvent + strings.ReplaceAll(uri, "/", "_")
+}
+
 func (wem *WebsocketEventManager) Registered() error {
-	if len(wem.registered) == 0 {
+	wem.mutex.RLock()
+	defer wem.mutex.RUnlock()
+
+	if wem.registered == 0 {
 		return &NoRegisteredEventError{}
 	}
 
 	return nil
 }
 
-func (wem *WebsocketEventManager) setRegistered(event map[string]interface{}) {
-	wem.registered = append(wem.registered, event)
-}
-
 func (wem *WebsocketEventManager) Match(message *Message) error {
-	for _, listener := range wem.registered {
-		if message.URI == listener["uri"] && message.Method == listener["method"] {
-			callback := listener["callback"].(WebsocketCallback)
-			response, err := json.Marshal(message.Data)
+	wem.mutex.RLock()
+	matches := wem.root.lookup(message.URI)
+	wem.mutex.RUnlock()
 
-			if err != nil {
-				return err
-			}
+	for _, match := range matches {
+		if !match.listener.accepts(message.Method) {
+			continue
+		}
 
-			callback(response)
+		if match.listener.once && !wem.remove(match.listener) {
+			continue
 		}
+
+		wem.invoke(match.listener, message, match.params)
 	}
 
 	return nil
 }
 
-func (wem *WebsocketEventManager) OnMessage(uri string, method string, callback WebsocketCallback) WebsocketCallback {
-	event := map[string]interface{}{
-		"uri":      uri,
-		"method":   method,
-		"callback": callback,
+func (response *Response) Message() (*Message, error) {
+	var header header
+	err := json.Unmarshal(response.Data, &header)
+
+	if err != nil {
+		return nil, &MalformedFrameError{string(response.Data), "the payload is not an object"}
 	}
 
-	wem.setRegistered(event)
-	return callback
+	if header.URI == nil {
+		return nil, &MalformedFrameError{string(response.Data), "the payload is missing a uri"}
+	}
+
+	if header.EventType == nil {
+		return nil, &MalformedFrameError{string(response.Data), "the payload is missing an eventType"}
+	}
+
+	return &Message{
+		URI:    *header.URI,
+		Method: *header.EventType,
+		Data:   response.Data,
+	}, nil
 }
 
 func (response *Response) UnmarshalJSON(message []byte) error {
-	return json.Unmarshal(
-		message,
-		&[]interface{}{
+	var frame []json.RawMessage
+	err := json.Unmarshal(message, &frame)
+
+	if err != nil {
+		return &MalformedFrameError{string(message), err.Error()}
+	}
+
+	if len(frame) == 0 {
+		return &MalformedFrameError{string(message), "empty frame"}
+	}
+
+	err = json.Unmarshal(frame[0], &response.MessageType)
+
+	if err != nil {
+		return &MalformedFrameError{string(message), "the message type is not a number"}
+	}
+
+	switch response.MessageType {
+	case Welcome:
+		return unmarshalFrame(
+			message,
+			frame,
+			2,
+			&response.MessageType,
+			&response.SessionID,
+			&response.Version,
+			&response.Server,
+		)
+	case CallResult:
+		return unmarshalFrame(
+			message,
+			frame,
+			2,
+			&response.MessageType,
+			&response.CallID,
+			&response.Result,
+		)
+	case CallError:
+		return unmarshalFrame(
+			message,
+			frame,
+			3,
+			&response.MessageType,
+			&response.CallID,
+			&response.ErrorURI,
+			&response.Description,
+			&response.Details,
+		)
+	case Event:
+		return unmarshalFrame(
+			message,
+			frame,
+			3,
 			&response.MessageType,
 			&response.Event,
 			&response.Data,
-		})
+		)
+	}
+
+	return nil
+}
+
+func unmarshalFrame(message []byte, frame []json.RawMessage, minimum int, fields ...interface{}) error {
+	if len(frame) < minimum {
+		return &MalformedFrameError{
+			string(message),
+			fmt.Sprintf("expected at least %d elements", minimum),
+		}
+	}
+
+	for index, field := range fields {
+		if index >= len(frame) {
+			break
+		}
+
+		err := json.Unmarshal(frame[index], field)
+
+		if err != nil {
+			return &MalformedFrameError{string(message), err.Error()}
+		}
+	}
+
+	return nil
 }
@@ -0,0 +1,133 @@
+package wem
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+var session = []byte(`[8,"OnJsonApiEvent",{"data":{"actions":[[{"actorCellId":0,"championId":0,"completed":true,"id":1,"isAllyAction":true,"isInProgress":false,"pickTurn":1,"type":"ban"},{"actorCellId":1,"championId":157,"completed":true,"id":2,"isAllyAction":true,"isInProgress":false,"pickTurn":1,"type":"ban"},{"actorCellId":2,"championId":0,"completed":false,"id":3,"isAllyAction":true,"isInProgress":true,"pickTurn":1,"type":"ban"},{"actorCellId":5,"championId":238,"completed":true,"id":4,"isAllyAction":false,"isInProgress":false,"pickTurn":1,"type":"ban"},{"actorCellId":6,"championId":0,"completed":false,"id":5,"isAllyAction":false,"isInProgress":true,"pickTurn":1,"type":"ban"}],[{"actorCellId":0,"championId":86,"completed":false,"id":11,"isAllyAction":true,"isInProgress":false,"pickTurn":2,"type":"pick"},{"actorCellId":5,"championId":0,"completed":false,"id":12,"isAllyAction":false,"isInProgress":false,"pickTurn":3,"type":"pick"}]],"allowBattleBoost":false,"allowDuplicatePicks":false,"allowLockedEvents":false,"allowRerolling":false,"allowSkinSelection":true,"bans":{"myTeamBans":[157],"numBans":10,"theirTeamBans":[238]},"benchChampions":[],"benchEnabled":false,"boostableSkinCount":1,"chatDetails":{"mucJwtDto":{"channelClaim":"","domain":"","jwt":"","targetRegion":""},"multiUserChatId":"c1~3f2a9c0b8e7d6f5a4b3c2d1e0f9a8b7c6d5e4f3a","multiUserChatPassword":"aGVsbG8gd29ybGQ="},"counter":42,"gameId":4822190311,"hasSimultaneousBans":true,"hasSimultaneousPicks":false,"isCustomGame":false,"isSpectating":false,"localPlayerCellId":0,"lockedEventIndex":-1,"myTeam":[{"assignedPosition":"top","cellId":0,"championId":86,"championPickIntent":0,"nameVisibilityType":"VISIBLE","obfuscatedPuuid":"","obfuscatedSummonerId":0,"puuid":"9b1e2c4d-5f6a-4b7c-8d9e-0f1a2b3c4d5e","selectedSkinId":86000,"spell1Id":4,"spell2Id":12,"summonerId":2881938423,"team":1,"wardSkinId":-1},{"assignedPosition":"jungle","cellId":1,"championId":0,"championPickIntent":64,"nameVisibilityType":"HIDDEN","obfuscatedPuuid":"a1b2c3","obfuscatedSummonerId":11,"puuid":"","selectedSkinId":0,"spell1Id":4,"spell2Id":11,"summonerId":0,"team":1,"wardSkinId":-1},{"assignedPosition":"middle","cellId":2,"championId":0,"championPickIntent":103,"nameVisibilityType":"HIDDEN","obfuscatedPuuid":"d4e5f6","obfuscatedSummonerId":12,"puuid":"","selectedSkinId":0,"spell1Id":4,"spell2Id":14,"summonerId":0,"team":1,"wardSkinId":-1},{"assignedPosition":"bottom","cellId":3,"championId":0,"championPickIntent":222,"nameVisibilityType":"HIDDEN","obfuscatedPuuid":"g7h8i9","obfuscatedSummonerId":13,"puuid":"","selectedSkinId":0,"spell1Id":4,"spell2Id":7,"summonerId":0,"team":1,"wardSkinId":-1},{"assignedPosition":"utility","cellId":4,"championId":0,"championPickIntent":412,"nameVisibilityType":"HIDDEN","obfuscatedPuuid":"j0k1l2","obfuscatedSummonerId":14,"puuid":"","selectedSkinId":0,"spell1Id":4,"spell2Id":3,"summonerId":0,"team":1,"wardSkinId":-1}],"pickOrderSwaps":[],"recoveryCounter":0,"rerollsRemaining":0,"skipChampionSelect":false,"theirTeam":[{"assignedPosition":"","cellId":5,"championId":0,"championPickIntent":0,"nameVisibilityType":"HIDDEN","obfuscatedPuuid":"m3n4o5","obfuscatedSummonerId":21,"puuid":"","selectedSkinId":0,"spell1Id":0,"spell2Id":0,"summonerId":0,"team":2,"wardSkinId":-1},{"assignedPosition":"","cellId":6,"championId":0,"championPickIntent":0,"nameVisibilityType":"HIDDEN","obfuscatedPuuid":"p6q7r8","obfuscatedSummonerId":22,"puuid":"","selectedSkinId":0,"spell1Id":0,"spell2Id":0,"summonerId":0,"team":2,"wardSkinId":-1}],"timer":{"adjustedTimeLeftInPhase":26874,"internalNowInEpochMs":1680350400000,"isInfinite":false,"phase":"BAN_PICK","totalTimeInPhase":30000},"trades":[]},"eventType":"Update","uri":"/lol-champ-select/v1/session"}]`)
+
+type phase struct {
+	Data struct {
+		Timer struct {
+			Phase string `json:"phase"`
+		} `json:"timer"`
+	} `json:"data"`
+}
+
+func handlers(b *testing.B, manager *WebsocketEventManager, callback RouteCallback) {
+	patterns := []string{
+		"/lol-champ-select/v1/session",
+		"/lol-champ-select/v1/**",
+		"/lol-gameflow/v1/gameflow-phase",
+		"/lol-lobby/v2/lobby/members/{id}",
+		"/lol-chat/v1/conversations/*/messages",
+	}
+
+	for _, pattern := range patterns {
+		_, err := manager.OnRoute(pattern, AnyMethod, callback)
+
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func decodePhase(data []byte, params Params) {
+	var phase phase
+	json.Unmarshal(data, &phase)
+}
+
+func BenchmarkRoute(b *testing.B) {
+	manager := NewWebsocketEventManager()
+	handlers(b, manager, decodePhase)
+
+	b.ReportAllocs()
+	b.SetBytes(int64(len(session)))
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		var response Response
+		err := json.Unmarshal(session, &response)
+
+		if err != nil {
+			b.Fatal(err)
+		}
+
+		message, err := response.Message()
+
+		if err != nil {
+			b.Fatal(err)
+		}
+
+		err = manager.Match(message)
+
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkRouteMap reproduces the path that preceded raw payloads: the frame
+// was decoded into a map, which was re-encoded for every matching handler.
+func BenchmarkRouteMap(b *testing.B) {
+	manager := NewWebsocketEventManager()
+
+	var data map[string]interface{}
+
+	handlers(b, manager, func([]byte, Params) {
+		payload, err := json.Marshal(data)
+
+		if err != nil {
+			b.Fatal(err)
+		}
+
+		decodePhase(payload, nil)
+	})
+
+	b.ReportAllocs()
+	b.SetBytes(int64(len(session)))
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		var frame []interface{}
+		err := json.Unmarshal(session, &frame)
+
+		if err != nil {
+			b.Fatal(err)
+		}
+
+		data = frame[2].(map[string]interface{})
+
+		err = manager.Match(
+			&Message{
+				URI:    data["uri"].(string),
+				Method: data["eventType"].(string),
+			},
+		)
+
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkMatch(b *testing.B) {
+	manager := NewWebsocketEventManager()
+	handlers(b, manager, decodePhase)
+
+	var response Response
+	json.Unmarshal(session, &response)
+	message, _ := response.Message()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		err := manager.Match(message)
+
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}